@@ -0,0 +1,90 @@
+package checkvist
+
+import "context"
+
+// api.go contains the service interfaces implemented by the concrete
+// services, for substituting fakes in code that uses the client.
+
+// ChecklistAPI is the interface implemented by ChecklistService.
+type ChecklistAPI interface {
+	List(ctx context.Context) ([]Checklist, error)
+	ListWithOptions(ctx context.Context, opts ListOptions) ([]Checklist, error)
+	Get(ctx context.Context, id int) (*Checklist, error)
+	Create(ctx context.Context, name string) (*Checklist, error)
+	Update(ctx context.Context, id int, name string) (*Checklist, error)
+	Delete(ctx context.Context, id int) error
+	Archive(ctx context.Context, id int) (*Checklist, error)
+	Unarchive(ctx context.Context, id int) (*Checklist, error)
+	Clone(ctx context.Context, sourceID int, opts CloneOptions) (*Checklist, error)
+}
+
+// TaskAPI is the interface implemented by TaskService.
+type TaskAPI interface {
+	List(ctx context.Context) ([]Task, error)
+	Get(ctx context.Context, taskID int) (*Task, error)
+	Tree(ctx context.Context) (*TaskTree, error)
+	Create(ctx context.Context, builder *TaskBuilder) (*Task, error)
+	CreateOutline(ctx context.Context, nodes []*OutlineNode, parentID, position int) ([]Task, error)
+	Import(ctx context.Context, outline string, opts ImportOptions) ([]Task, error)
+	Update(ctx context.Context, taskID int, req UpdateTaskRequest) (*Task, error)
+	AddTags(ctx context.Context, taskID int, tags ...string) (*Task, error)
+	RemoveTags(ctx context.Context, taskID int, tags ...string) (*Task, error)
+	UpdateTags(ctx context.Context, taskID int, update TagUpdate) (*Task, error)
+	Move(ctx context.Context, taskID int, target MoveTarget) (*Task, error)
+	Delete(ctx context.Context, taskID int) error
+	Close(ctx context.Context, taskID int) (*Task, error)
+	Reopen(ctx context.Context, taskID int) (*Task, error)
+	Invalidate(ctx context.Context, taskID int) (*Task, error)
+}
+
+// NoteAPI is the interface implemented by NoteService.
+type NoteAPI interface {
+	List(ctx context.Context) ([]Note, error)
+	Create(ctx context.Context, comment string) (*Note, error)
+	Update(ctx context.Context, noteID int, comment string) (*Note, error)
+	Delete(ctx context.Context, noteID int) error
+}
+
+// API is the top-level interface for accessing the services of a client.
+// Use Client.API to obtain one from a Client, or the checkvistmock package
+// for a mock implementation.
+type API interface {
+	Checklists() ChecklistAPI
+	Tasks(checklistID int) TaskAPI
+	Notes(checklistID, taskID int) NoteAPI
+	CurrentUser(ctx context.Context) (*User, error)
+}
+
+var (
+	_ ChecklistAPI = (*ChecklistService)(nil)
+	_ TaskAPI      = (*TaskService)(nil)
+	_ NoteAPI      = (*NoteService)(nil)
+	_ API          = clientAPI{}
+)
+
+// API returns the client as an API interface. The services it returns are
+// the same as those of Checklists, Tasks and Notes.
+func (c *Client) API() API {
+	return clientAPI{c}
+}
+
+// clientAPI adapts a Client to the API interface.
+type clientAPI struct {
+	client *Client
+}
+
+func (a clientAPI) Checklists() ChecklistAPI {
+	return a.client.Checklists()
+}
+
+func (a clientAPI) Tasks(checklistID int) TaskAPI {
+	return a.client.Tasks(checklistID)
+}
+
+func (a clientAPI) Notes(checklistID, taskID int) NoteAPI {
+	return a.client.Notes(checklistID, taskID)
+}
+
+func (a clientAPI) CurrentUser(ctx context.Context) (*User, error) {
+	return a.client.CurrentUser(ctx)
+}
@@ -0,0 +1,318 @@
+package checkvist
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// backup.go contains full account backup and restore using versioned
+// JSON or tar.gz archives.
+
+// BackupVersion is the archive format version written by WriteBackup.
+const BackupVersion = 1
+
+// BackupFormat selects the archive format written by WriteBackup.
+type BackupFormat int
+
+const (
+	// BackupJSON writes the backup as a single JSON document.
+	BackupJSON BackupFormat = iota
+	// BackupTarGz writes a gzip-compressed tar archive containing manifest.json
+	// and one checklists/<id>.json file per checklist.
+	BackupTarGz
+)
+
+// Backup is a snapshot of the checklists, tasks and notes of an account.
+type Backup struct {
+	// Manifest describes the backup.
+	Manifest BackupManifest `json:"manifest"`
+	// Checklists contains the backed-up checklists with their tasks.
+	Checklists []ChecklistBackup `json:"checklists"`
+}
+
+// BackupManifest describes the contents of a backup.
+type BackupManifest struct {
+	// Version is the archive format version.
+	Version int `json:"version"`
+	// CreatedAt is the time the backup was taken.
+	CreatedAt time.Time `json:"created_at"`
+	// Username is the account the backup was taken from.
+	Username string `json:"username"`
+	// Checklists is the number of checklists in the backup.
+	Checklists int `json:"checklists"`
+	// Tasks is the number of tasks in the backup.
+	Tasks int `json:"tasks"`
+	// Notes is the number of notes in the backup.
+	Notes int `json:"notes"`
+}
+
+// ChecklistBackup is a single checklist with all of its tasks.
+// The notes of each task are stored in Task.Notes.
+type ChecklistBackup struct {
+	// Checklist is the backed-up checklist.
+	Checklist Checklist `json:"checklist"`
+	// Tasks contains all tasks of the checklist.
+	Tasks []Task `json:"tasks"`
+}
+
+// BackupOptions configures the Backup operation.
+type BackupOptions struct {
+	// IncludeArchived also backs up archived checklists.
+	IncludeArchived bool
+	// Progress is called after each checklist has been backed up.
+	Progress func(checklist Checklist)
+}
+
+// Backup fetches every checklist with its tasks and notes.
+func (c *Client) Backup(ctx context.Context, opts BackupOptions) (*Backup, error) {
+	checklists, err := c.Checklists().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing checklists: %w", err)
+	}
+	if opts.IncludeArchived {
+		archived, err := c.Checklists().ListWithOptions(ctx, ListOptions{Archived: true})
+		if err != nil {
+			return nil, fmt.Errorf("listing archived checklists: %w", err)
+		}
+		seen := make(map[int]bool, len(checklists))
+		for _, cl := range checklists {
+			seen[cl.ID] = true
+		}
+		for _, cl := range archived {
+			if !seen[cl.ID] {
+				checklists = append(checklists, cl)
+			}
+		}
+	}
+
+	backup := &Backup{
+		Manifest: BackupManifest{
+			Version:   BackupVersion,
+			CreatedAt: time.Now().UTC(),
+			Username:  c.username,
+		},
+	}
+
+	for _, cl := range checklists {
+		service := c.Tasks(cl.ID)
+		tasks, err := service.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing tasks of checklist %d: %w", cl.ID, err)
+		}
+		for i := range tasks {
+			notes, err := service.sourceNotes(ctx, tasks[i])
+			if err != nil {
+				return nil, fmt.Errorf("listing notes of task %d: %w", tasks[i].ID, err)
+			}
+			tasks[i].Notes = notes
+			backup.Manifest.Notes += len(notes)
+		}
+
+		backup.Checklists = append(backup.Checklists, ChecklistBackup{Checklist: cl, Tasks: tasks})
+		backup.Manifest.Checklists++
+		backup.Manifest.Tasks += len(tasks)
+
+		if opts.Progress != nil {
+			opts.Progress(cl)
+		}
+	}
+
+	return backup, nil
+}
+
+// RestoreResult maps the IDs in a backup to the IDs of the restored objects.
+type RestoreResult struct {
+	// Checklists maps backed-up checklist IDs to restored checklist IDs.
+	Checklists map[int]int
+	// Tasks maps backed-up task IDs to restored task IDs.
+	Tasks map[int]int
+}
+
+// Restore recreates the checklists, task trees and notes of a backup as new
+// checklists. Archived checklists are archived again after restoring.
+// Restore is intended for an empty account; existing checklists are left
+// untouched, so restoring into a populated account creates duplicates.
+//
+// If restoring fails part-way, the mapping of what has been restored so far
+// is returned together with the error.
+func (c *Client) Restore(ctx context.Context, backup *Backup) (*RestoreResult, error) {
+	if backup.Manifest.Version > BackupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", backup.Manifest.Version)
+	}
+
+	result := &RestoreResult{
+		Checklists: make(map[int]int, len(backup.Checklists)),
+		Tasks:      make(map[int]int),
+	}
+
+	for _, cb := range backup.Checklists {
+		cl, err := c.Checklists().Create(ctx, cb.Checklist.Name)
+		if err != nil {
+			return result, fmt.Errorf("restoring checklist %d: %w", cb.Checklist.ID, err)
+		}
+		result.Checklists[cb.Checklist.ID] = cl.ID
+
+		tree := NewTaskTree(cb.Tasks)
+		dst := c.Tasks(cl.ID)
+		opts := copyOptions{
+			notes: func(_ context.Context, task Task) ([]Note, error) {
+				return task.Notes, nil
+			},
+			created: func(src Task, dst *Task) {
+				result.Tasks[src.ID] = dst.ID
+			},
+		}
+		for _, root := range tree.Roots() {
+			if _, err := dst.copySubtree(ctx, root, 0, 0, opts); err != nil {
+				return result, fmt.Errorf("restoring checklist %d: %w", cb.Checklist.ID, err)
+			}
+		}
+
+		if cb.Checklist.Archived {
+			if _, err := c.Checklists().Archive(ctx, cl.ID); err != nil {
+				return result, fmt.Errorf("archiving restored checklist %d: %w", cl.ID, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// WriteBackup writes the backup to w in the given format.
+func WriteBackup(w io.Writer, backup *Backup, format BackupFormat) error {
+	switch format {
+	case BackupJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(backup); err != nil {
+			return fmt.Errorf("encoding backup: %w", err)
+		}
+		return nil
+	case BackupTarGz:
+		return writeBackupTarGz(w, backup)
+	default:
+		return fmt.Errorf("unknown backup format %d", format)
+	}
+}
+
+// writeBackupTarGz writes the backup as a gzip-compressed tar archive.
+func writeBackupTarGz(w io.Writer, backup *Backup) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	addFile := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", name, err)
+		}
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: backup.Manifest.CreatedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		return nil
+	}
+
+	if err := addFile("manifest.json", backup.Manifest); err != nil {
+		return err
+	}
+	for _, cb := range backup.Checklists {
+		if err := addFile(fmt.Sprintf("checklists/%d.json", cb.Checklist.ID), cb); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("closing tar archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("closing gzip stream: %w", err)
+	}
+	return nil
+}
+
+// ReadBackup reads a backup written by WriteBackup. The format is detected
+// automatically.
+func ReadBackup(r io.Reader) (*Backup, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err != nil {
+		return nil, fmt.Errorf("reading backup: %w", err)
+	}
+
+	var backup *Backup
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		backup, err = readBackupTarGz(br)
+	} else {
+		backup = &Backup{}
+		if err = json.NewDecoder(br).Decode(backup); err != nil {
+			err = fmt.Errorf("decoding backup: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if backup.Manifest.Version == 0 || backup.Manifest.Version > BackupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", backup.Manifest.Version)
+	}
+	for i := range backup.Checklists {
+		parseChecklist(&backup.Checklists[i].Checklist)
+		for j := range backup.Checklists[i].Tasks {
+			parseTask(&backup.Checklists[i].Tasks[j])
+		}
+	}
+	return backup, nil
+}
+
+// readBackupTarGz reads a backup from a gzip-compressed tar archive.
+func readBackupTarGz(r io.Reader) (*Backup, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("opening gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	backup := &Backup{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading tar archive: %w", err)
+		}
+
+		switch {
+		case hdr.Name == "manifest.json":
+			if err := json.NewDecoder(tr).Decode(&backup.Manifest); err != nil {
+				return nil, fmt.Errorf("decoding manifest: %w", err)
+			}
+		case path.Dir(hdr.Name) == "checklists" && strings.HasSuffix(hdr.Name, ".json"):
+			var cb ChecklistBackup
+			if err := json.NewDecoder(tr).Decode(&cb); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", hdr.Name, err)
+			}
+			backup.Checklists = append(backup.Checklists, cb)
+		}
+	}
+
+	return backup, nil
+}
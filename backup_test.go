@@ -0,0 +1,182 @@
+package checkvist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Backup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists.json":
+			if r.URL.Query().Get("archived") == "true" {
+				json.NewEncoder(w).Encode([]Checklist{{ID: 2, Name: "Old", Archived: true}})
+				return
+			}
+			json.NewEncoder(w).Encode([]Checklist{{ID: 1, Name: "Current"}})
+		case "/checklists/1/tasks.json":
+			json.NewEncoder(w).Encode([]Task{
+				{ID: 10, Content: "Parent", CommentsCount: 1},
+				{ID: 11, ParentID: 10, Content: "Child"},
+			})
+		case "/checklists/2/tasks.json":
+			json.NewEncoder(w).Encode([]Task{{ID: 20, Content: "Archived task"}})
+		case "/checklists/1/tasks/10/comments.json":
+			json.NewEncoder(w).Encode([]Note{{ID: 5, TaskID: 10, Comment: "Note"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var progress []int
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	backup, err := client.Backup(context.Background(), BackupOptions{
+		IncludeArchived: true,
+		Progress:        func(cl Checklist) { progress = append(progress, cl.ID) },
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m := backup.Manifest
+	if m.Version != BackupVersion || m.Username != "user@example.com" {
+		t.Errorf("unexpected manifest: %+v", m)
+	}
+	if m.Checklists != 2 || m.Tasks != 3 || m.Notes != 1 {
+		t.Errorf("expected 2 checklists, 3 tasks, 1 note, got %+v", m)
+	}
+	if len(backup.Checklists[0].Tasks[0].Notes) != 1 {
+		t.Errorf("expected notes to be stored on the task")
+	}
+	if len(progress) != 2 || progress[1] != 2 {
+		t.Errorf("expected progress [1 2], got %v", progress)
+	}
+}
+
+func backupFixture() *Backup {
+	return &Backup{
+		Manifest: BackupManifest{Version: BackupVersion, CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Checklists: 2, Tasks: 3, Notes: 1},
+		Checklists: []ChecklistBackup{
+			{
+				Checklist: Checklist{ID: 1, Name: "Current", TagsAsText: "work"},
+				Tasks: []Task{
+					{ID: 10, Content: "Parent", DueDateRaw: "2026/02/01", TagsAsText: "a, b",
+						Notes: []Note{{ID: 5, TaskID: 10, Comment: "Note"}}},
+					{ID: 11, ParentID: 10, Content: "Child", Status: StatusClosed},
+				},
+			},
+			{
+				Checklist: Checklist{ID: 2, Name: "Old", Archived: true},
+				Tasks:     []Task{{ID: 20, Content: "Archived task"}},
+			},
+		},
+	}
+}
+
+func TestBackup_WriteRead(t *testing.T) {
+	for _, format := range []BackupFormat{BackupJSON, BackupTarGz} {
+		t.Run(fmt.Sprintf("format %d", format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteBackup(&buf, backupFixture(), format); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			backup, err := ReadBackup(&buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if backup.Manifest.Tasks != 3 || len(backup.Checklists) != 2 {
+				t.Fatalf("unexpected backup: %+v", backup.Manifest)
+			}
+			cl := backup.Checklists[0]
+			if !cl.Checklist.Tags.Has("work") {
+				t.Errorf("expected checklist tags to be parsed")
+			}
+			parent := cl.Tasks[0]
+			if parent.DueDate == nil || !parent.Tags.Has("b") || len(parent.Notes) != 1 {
+				t.Errorf("expected derived fields and notes to be restored, got %+v", parent)
+			}
+			if !backup.Checklists[1].Checklist.Archived {
+				t.Errorf("expected archived flag to be preserved")
+			}
+		})
+	}
+
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := ReadBackup(strings.NewReader(`{"manifest":{"version":99}}`))
+		if err == nil || !strings.Contains(err.Error(), "unsupported backup version") {
+			t.Errorf("expected version error, got %v", err)
+		}
+	})
+}
+
+func TestClient_Restore(t *testing.T) {
+	var (
+		checklistID = 100
+		taskID      = 1000
+		notes       []string
+		archived    []string
+		closed      []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case r.URL.Path == "/checklists.json":
+			checklistID++
+			json.NewEncoder(w).Encode(Checklist{ID: checklistID})
+		case strings.HasSuffix(r.URL.Path, "/tasks.json"):
+			taskID++
+			json.NewEncoder(w).Encode(Task{ID: taskID})
+		case strings.HasSuffix(r.URL.Path, "/comments.json"):
+			notes = append(notes, r.URL.Path)
+			json.NewEncoder(w).Encode(Note{ID: 1})
+		case strings.HasSuffix(r.URL.Path, "/close.json"):
+			closed = append(closed, r.URL.Path)
+			json.NewEncoder(w).Encode([]Task{{ID: 1002, Status: StatusClosed}})
+		case r.Method == http.MethodPut:
+			archived = append(archived, r.URL.Path)
+			json.NewEncoder(w).Encode(Checklist{ID: 102, Archived: true})
+		default:
+			t.Errorf("unexpected path: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	result, err := client.Restore(context.Background(), backupFixture())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Checklists[1] != 101 || result.Checklists[2] != 102 {
+		t.Errorf("unexpected checklist mapping: %v", result.Checklists)
+	}
+	if result.Tasks[10] != 1001 || result.Tasks[11] != 1002 || result.Tasks[20] != 1003 {
+		t.Errorf("unexpected task mapping: %v", result.Tasks)
+	}
+	if len(notes) != 1 || notes[0] != "/checklists/101/tasks/1001/comments.json" {
+		t.Errorf("unexpected notes: %v", notes)
+	}
+	if len(closed) != 1 {
+		t.Errorf("expected closed status to be restored, got %v", closed)
+	}
+	if len(archived) != 1 || archived[0] != "/checklists/102.json" {
+		t.Errorf("expected checklist 102 to be archived, got %v", archived)
+	}
+}
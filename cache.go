@@ -0,0 +1,156 @@
+package checkvist
+
+import (
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// cache.go contains the conditional GET response cache.
+
+// CacheEntry is a cached API response with its validators.
+type CacheEntry struct {
+	// ETag is the value of the ETag response header, if any.
+	ETag string
+	// LastModified is the value of the Last-Modified response header, if any.
+	LastModified string
+	// Body is the response body.
+	Body []byte
+}
+
+// Cache stores responses of GET requests for conditional requests.
+// Keys consist of the username and the request path, so a cache can be
+// shared between clients of different users.
+//
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the entry for the key, if present.
+	Get(key string) (CacheEntry, bool)
+	// Set stores the entry for the key.
+	Set(key string, entry CacheEntry)
+	// DeletePrefix removes all entries whose key starts with prefix.
+	DeletePrefix(prefix string)
+}
+
+// LRUCache is an in-memory Cache that evicts the least recently used entries
+// once it holds more than its maximum number of entries.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// lruItem is an element of the LRU list.
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCache creates an LRU cache holding up to size entries.
+// A size below 1 is treated as 1.
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *LRUCache) Get(key string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruItem).entry, true
+}
+
+// Set implements Cache.
+func (c *LRUCache) Set(key string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruItem{key: key, entry: entry})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+// DeletePrefix implements Cache.
+func (c *LRUCache) DeletePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, elem := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.order.Remove(elem)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// cacheKey returns the cache key for a request path.
+func (c *Client) cacheKey(path string) string {
+	return c.username + " " + path
+}
+
+// setConditionalHeaders adds the validators of a cached entry to the request.
+func setConditionalHeaders(req *http.Request, entry CacheEntry) {
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+}
+
+// storeResponse caches a GET response if it carries a validator.
+func (c *Client) storeResponse(path string, resp *http.Response, body []byte) {
+	entry := CacheEntry{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         body,
+	}
+	if entry.ETag == "" && entry.LastModified == "" {
+		return
+	}
+	c.cache.Set(c.cacheKey(path), entry)
+}
+
+// invalidateCache removes the cached responses affected by a write to path:
+// everything cached for the checklist the path belongs to and the checklist
+// lists. Writes outside a single checklist, such as moves between
+// checklists, invalidate all of the user's entries.
+func (c *Client) invalidateCache(path string) {
+	path, _, _ = strings.Cut(path, "?")
+	c.cache.DeletePrefix(c.cacheKey("/checklists.json"))
+
+	rest, ok := strings.CutPrefix(path, "/checklists/")
+	if !ok || strings.HasSuffix(path, "/move.json") {
+		c.cache.DeletePrefix(c.cacheKey(""))
+		return
+	}
+	id, _, _ := strings.Cut(strings.TrimSuffix(rest, ".json"), "/")
+	c.cache.DeletePrefix(c.cacheKey("/checklists/" + id + ".json"))
+	c.cache.DeletePrefix(c.cacheKey("/checklists/" + id + "/"))
+}
@@ -0,0 +1,133 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", CacheEntry{ETag: "1"})
+	cache.Set("b", CacheEntry{ETag: "2"})
+	cache.Get("a")
+	cache.Set("c", CacheEntry{ETag: "3"})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected least recently used entry to be evicted")
+	}
+	if entry, ok := cache.Get("a"); !ok || entry.ETag != "1" {
+		t.Errorf("expected recently used entry to be kept, got %+v", entry)
+	}
+
+	cache.Set("a", CacheEntry{ETag: "updated"})
+	if entry, _ := cache.Get("a"); entry.ETag != "updated" || cache.Len() != 2 {
+		t.Errorf("expected entry to be replaced, got %+v with %d entries", entry, cache.Len())
+	}
+
+	cache.DeletePrefix("a")
+	if _, ok := cache.Get("a"); ok || cache.Len() != 1 {
+		t.Errorf("expected prefix deletion, got %d entries", cache.Len())
+	}
+}
+
+func TestClient_Cache(t *testing.T) {
+	var downloads, notModified int32
+	version := "v1"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case r.URL.Path == "/checklists/1/tasks.json" && r.Method == http.MethodGet:
+			etag := `"` + version + `"`
+			if r.Header.Get("If-None-Match") == etag {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			atomic.AddInt32(&downloads, 1)
+			w.Header().Set("ETag", etag)
+			json.NewEncoder(w).Encode([]Task{{ID: 1, Content: "Task " + version}})
+		case r.URL.Path == "/checklists/1/tasks.json" && r.Method == http.MethodPost:
+			json.NewEncoder(w).Encode(Task{ID: 2})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	cache := NewLRUCache(10)
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL), WithCache(cache))
+	ctx := context.Background()
+	tasks := client.Tasks(1)
+
+	for i := 0; i < 3; i++ {
+		list, err := tasks.List(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(list) != 1 || list[0].Content != "Task v1" {
+			t.Fatalf("unexpected tasks: %+v", list)
+		}
+	}
+	if downloads != 1 || notModified != 2 {
+		t.Errorf("expected 1 download and 2 cache hits, got %d and %d", downloads, notModified)
+	}
+
+	// A write to the checklist invalidates its cached responses, so the next
+	// list request is unconditional even though the server would answer 304
+	if _, err := tasks.Create(ctx, NewTask("New")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := tasks.List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if downloads != 2 {
+		t.Errorf("expected a fresh download after a write, got %d downloads", downloads)
+	}
+
+	// Another user sharing the cache does not see this user's entries
+	other := NewClient("other@example.com", "api-key", WithBaseURL(server.URL), WithCache(cache))
+	if _, err := other.Tasks(1).List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if downloads != 3 {
+		t.Errorf("expected cache to be keyed by user, got %d downloads", downloads)
+	}
+}
+
+func TestClient_InvalidateCache(t *testing.T) {
+	cache := NewLRUCache(10)
+	client := NewClient("user@example.com", "api-key", WithCache(cache))
+	for _, path := range []string{
+		"/checklists.json", "/checklists.json?archived=true", "/checklists/1.json",
+		"/checklists/1/tasks.json", "/checklists/10/tasks.json", "/auth/curr_user.json",
+	} {
+		cache.Set(client.cacheKey(path), CacheEntry{ETag: "x"})
+	}
+
+	client.invalidateCache("/checklists/1/tasks/5/close.json")
+	for path, kept := range map[string]bool{
+		"/checklists.json":               false,
+		"/checklists.json?archived=true": false,
+		"/checklists/1.json":             false,
+		"/checklists/1/tasks.json":       false,
+		"/checklists/10/tasks.json":      true,
+		"/auth/curr_user.json":           true,
+	} {
+		if _, ok := cache.Get(client.cacheKey(path)); ok != kept {
+			t.Errorf("%s: expected kept=%v", path, kept)
+		}
+	}
+
+	client.invalidateCache("/checklists/10/tasks/5/move.json")
+	if cache.Len() != 0 {
+		t.Errorf("expected moves to invalidate all entries, got %d left", cache.Len())
+	}
+}
@@ -39,6 +39,10 @@ func (s *ChecklistService) ListWithOptions(ctx context.Context, opts ListOptions
 	if err := s.client.doGet(ctx, path, &checklists); err != nil {
 		return nil, err
 	}
+
+	for i := range checklists {
+		parseChecklist(&checklists[i])
+	}
 	return checklists, nil
 }
 
@@ -50,6 +54,8 @@ func (s *ChecklistService) Get(ctx context.Context, id int) (*Checklist, error)
 	if err := s.client.doGet(ctx, path, &checklist); err != nil {
 		return nil, err
 	}
+
+	parseChecklist(&checklist)
 	return &checklist, nil
 }
 
@@ -66,6 +72,8 @@ func (s *ChecklistService) Create(ctx context.Context, name string) (*Checklist,
 	if err := s.client.doPost(ctx, "/checklists.json", body, &checklist); err != nil {
 		return nil, err
 	}
+
+	parseChecklist(&checklist)
 	return &checklist, nil
 }
 
@@ -83,6 +91,8 @@ func (s *ChecklistService) Update(ctx context.Context, id int, name string) (*Ch
 	if err := s.client.doPut(ctx, path, body, &checklist); err != nil {
 		return nil, err
 	}
+
+	parseChecklist(&checklist)
 	return &checklist, nil
 }
 
@@ -106,6 +116,8 @@ func (s *ChecklistService) Archive(ctx context.Context, id int) (*Checklist, err
 	if err := s.client.doPut(ctx, path, body, &checklist); err != nil {
 		return nil, err
 	}
+
+	parseChecklist(&checklist)
 	return &checklist, nil
 }
 
@@ -118,5 +130,12 @@ func (s *ChecklistService) Unarchive(ctx context.Context, id int) (*Checklist, e
 	if err := s.client.doPut(ctx, path, body, &checklist); err != nil {
 		return nil, err
 	}
+
+	parseChecklist(&checklist)
 	return &checklist, nil
 }
+
+// parseChecklist fills the derived fields of a checklist decoded from an API response.
+func parseChecklist(checklist *Checklist) {
+	checklist.Tags = ParseTags(checklist.TagsAsText)
+}
@@ -47,6 +47,9 @@ func TestChecklists_List(t *testing.T) {
 	if checklists[1].TaskCount != 25 {
 		t.Errorf("expected TaskCount 25, got %d", checklists[1].TaskCount)
 	}
+	if !checklists[1].Tags.Has("work") || !checklists[1].Tags.Has("important") {
+		t.Errorf("expected parsed tags work and important, got %v", checklists[1].Tags)
+	}
 }
 
 func TestChecklists_ListArchived(t *testing.T) {
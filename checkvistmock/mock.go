@@ -0,0 +1,148 @@
+// Package checkvistmock provides mock implementations of the checkvist
+// service interfaces for unit tests of code that depends on checkvist.API,
+// checkvist.ChecklistAPI, checkvist.TaskAPI or checkvist.NoteAPI.
+//
+// Responses are programmed by setting the Func fields of a mock; every call
+// is recorded and can be inspected with Calls and CallsTo:
+//
+//	api := checkvistmock.NewAPI()
+//	api.Task.CloseFunc = func(ctx context.Context, taskID int) (*checkvist.Task, error) {
+//		return &checkvist.Task{ID: taskID, Status: checkvist.StatusClosed}, nil
+//	}
+//
+//	err := completeTask(ctx, api, 1, 42) // code under test
+//
+//	if calls := api.Task.CallsTo("Close"); len(calls) != 1 || calls[0].Args[0] != 42 {
+//		t.Errorf("expected task 42 to be closed, got %v", calls)
+//	}
+package checkvistmock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+// mock.go contains the call recorder and the mock of checkvist.API.
+
+// ErrUnexpectedCall is returned by mock methods whose Func field is not set.
+var ErrUnexpectedCall = errors.New("checkvistmock: unexpected call")
+
+// unexpected returns an error wrapping ErrUnexpectedCall for the method.
+func unexpected(method string) error {
+	return fmt.Errorf("%w to %s", ErrUnexpectedCall, method)
+}
+
+// Call is a recorded method call.
+type Call struct {
+	// Method is the name of the called method.
+	Method string
+	// Args are the arguments of the call, excluding the context.
+	Args []any
+}
+
+// Recorder records the calls made to a mock. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// record appends a call.
+func (r *Recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to the named method in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+// Reset discards the recorded calls.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// API is a mock of checkvist.API. By default, Checklists, Tasks and Notes
+// return the Checklist, Task and Note mocks regardless of their arguments;
+// set TasksFunc or NotesFunc to return different mocks per checklist or task.
+// The calls to Tasks and Notes are recorded with their IDs.
+type API struct {
+	Recorder
+
+	// Checklist is returned by Checklists.
+	Checklist *ChecklistAPI
+	// Task is returned by Tasks unless TasksFunc is set.
+	Task *TaskAPI
+	// Note is returned by Notes unless NotesFunc is set.
+	Note *NoteAPI
+
+	TasksFunc       func(checklistID int) checkvist.TaskAPI
+	NotesFunc       func(checklistID, taskID int) checkvist.NoteAPI
+	CurrentUserFunc func(ctx context.Context) (*checkvist.User, error)
+}
+
+var _ checkvist.API = (*API)(nil)
+
+// NewAPI creates an API mock with empty service mocks.
+func NewAPI() *API {
+	return &API{
+		Checklist: &ChecklistAPI{},
+		Task:      &TaskAPI{},
+		Note:      &NoteAPI{},
+	}
+}
+
+// Checklists implements checkvist.API.
+func (m *API) Checklists() checkvist.ChecklistAPI {
+	m.record("Checklists")
+	return m.Checklist
+}
+
+// Tasks implements checkvist.API.
+func (m *API) Tasks(checklistID int) checkvist.TaskAPI {
+	m.record("Tasks", checklistID)
+	if m.TasksFunc != nil {
+		return m.TasksFunc(checklistID)
+	}
+	return m.Task
+}
+
+// Notes implements checkvist.API.
+func (m *API) Notes(checklistID, taskID int) checkvist.NoteAPI {
+	m.record("Notes", checklistID, taskID)
+	if m.NotesFunc != nil {
+		return m.NotesFunc(checklistID, taskID)
+	}
+	return m.Note
+}
+
+// CurrentUser implements checkvist.API.
+func (m *API) CurrentUser(ctx context.Context) (*checkvist.User, error) {
+	m.record("CurrentUser")
+	if m.CurrentUserFunc == nil {
+		return nil, unexpected("API.CurrentUser")
+	}
+	return m.CurrentUserFunc(ctx)
+}
@@ -0,0 +1,93 @@
+package checkvistmock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+	"code.beautifulmachines.dev/jakoubek/checkvist-api/checkvisttest"
+)
+
+// closeWithNote is an example of business logic written against checkvist.API.
+func closeWithNote(ctx context.Context, api checkvist.API, checklistID, taskID int, note string) error {
+	if _, err := api.Notes(checklistID, taskID).Create(ctx, note); err != nil {
+		return err
+	}
+	_, err := api.Tasks(checklistID).Close(ctx, taskID)
+	return err
+}
+
+func TestAPI_ProgrammedResponses(t *testing.T) {
+	api := NewAPI()
+	api.Note.CreateFunc = func(ctx context.Context, comment string) (*checkvist.Note, error) {
+		return &checkvist.Note{ID: 1, Comment: comment}, nil
+	}
+	api.Task.CloseFunc = func(ctx context.Context, taskID int) (*checkvist.Task, error) {
+		return &checkvist.Task{ID: taskID, Status: checkvist.StatusClosed}, nil
+	}
+
+	if err := closeWithNote(context.Background(), api, 1, 42, "Done"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls := api.CallsTo("Notes"); len(calls) != 1 || calls[0].Args[0] != 1 || calls[0].Args[1] != 42 {
+		t.Errorf("unexpected Notes calls: %v", calls)
+	}
+	if calls := api.Note.Calls(); len(calls) != 1 || calls[0].Method != "Create" || calls[0].Args[0] != "Done" {
+		t.Errorf("unexpected note calls: %v", calls)
+	}
+	if calls := api.Task.CallsTo("Close"); len(calls) != 1 || calls[0].Args[0] != 42 {
+		t.Errorf("unexpected Close calls: %v", calls)
+	}
+
+	api.Task.Reset()
+	if len(api.Task.Calls()) != 0 {
+		t.Errorf("expected calls to be reset")
+	}
+}
+
+func TestAPI_UnexpectedCall(t *testing.T) {
+	api := NewAPI()
+	api.Note.CreateFunc = func(ctx context.Context, comment string) (*checkvist.Note, error) {
+		return &checkvist.Note{}, nil
+	}
+
+	err := closeWithNote(context.Background(), api, 1, 42, "Done")
+	if !errors.Is(err, ErrUnexpectedCall) {
+		t.Fatalf("expected ErrUnexpectedCall, got %v", err)
+	}
+	if err.Error() != "checkvistmock: unexpected call to TaskAPI.Close" {
+		t.Errorf("unexpected message: %v", err)
+	}
+}
+
+func TestAPI_TasksFunc(t *testing.T) {
+	api := NewAPI()
+	perChecklist := map[int]*TaskAPI{1: {}, 2: {}}
+	api.TasksFunc = func(checklistID int) checkvist.TaskAPI { return perChecklist[checklistID] }
+
+	api.Tasks(2).AddTags(context.Background(), 7, "a", "b")
+	if calls := perChecklist[2].CallsTo("AddTags"); len(calls) != 1 {
+		t.Errorf("expected call on checklist 2 mock, got %v", perChecklist[2].Calls())
+	}
+	if len(perChecklist[1].Calls()) != 0 {
+		t.Errorf("expected no calls on checklist 1 mock")
+	}
+}
+
+func TestClientAPI(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	cl := server.AddChecklist("Real")
+	task := server.AddTask(cl.ID, checkvist.Task{Content: "Task"})
+
+	// The same business logic runs against the real client
+	api := server.Client().API()
+	if err := closeWithNote(context.Background(), api, cl.ID, task.ID, "Done"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tasks := server.Tasks(cl.ID); tasks[0].Status != checkvist.StatusClosed || tasks[0].CommentsCount != 1 {
+		t.Errorf("unexpected task state: %+v", tasks[0])
+	}
+}
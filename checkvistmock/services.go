@@ -0,0 +1,316 @@
+package checkvistmock
+
+import (
+	"context"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+// services.go contains the mocks of the service interfaces.
+
+// ChecklistAPI is a mock of checkvist.ChecklistAPI. Each method records the call
+// and delegates to the corresponding Func field, or returns an error
+// wrapping ErrUnexpectedCall if the field is nil.
+type ChecklistAPI struct {
+	Recorder
+	ListFunc            func(ctx context.Context) ([]checkvist.Checklist, error)
+	ListWithOptionsFunc func(ctx context.Context, opts checkvist.ListOptions) ([]checkvist.Checklist, error)
+	GetFunc             func(ctx context.Context, id int) (*checkvist.Checklist, error)
+	CreateFunc          func(ctx context.Context, name string) (*checkvist.Checklist, error)
+	UpdateFunc          func(ctx context.Context, id int, name string) (*checkvist.Checklist, error)
+	DeleteFunc          func(ctx context.Context, id int) error
+	ArchiveFunc         func(ctx context.Context, id int) (*checkvist.Checklist, error)
+	UnarchiveFunc       func(ctx context.Context, id int) (*checkvist.Checklist, error)
+	CloneFunc           func(ctx context.Context, sourceID int, opts checkvist.CloneOptions) (*checkvist.Checklist, error)
+}
+
+var _ checkvist.ChecklistAPI = (*ChecklistAPI)(nil)
+
+// List implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) List(ctx context.Context) ([]checkvist.Checklist, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, unexpected("ChecklistAPI.List")
+	}
+	return m.ListFunc(ctx)
+}
+
+// ListWithOptions implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) ListWithOptions(ctx context.Context, opts checkvist.ListOptions) ([]checkvist.Checklist, error) {
+	m.record("ListWithOptions", opts)
+	if m.ListWithOptionsFunc == nil {
+		return nil, unexpected("ChecklistAPI.ListWithOptions")
+	}
+	return m.ListWithOptionsFunc(ctx, opts)
+}
+
+// Get implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) Get(ctx context.Context, id int) (*checkvist.Checklist, error) {
+	m.record("Get", id)
+	if m.GetFunc == nil {
+		return nil, unexpected("ChecklistAPI.Get")
+	}
+	return m.GetFunc(ctx, id)
+}
+
+// Create implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) Create(ctx context.Context, name string) (*checkvist.Checklist, error) {
+	m.record("Create", name)
+	if m.CreateFunc == nil {
+		return nil, unexpected("ChecklistAPI.Create")
+	}
+	return m.CreateFunc(ctx, name)
+}
+
+// Update implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) Update(ctx context.Context, id int, name string) (*checkvist.Checklist, error) {
+	m.record("Update", id, name)
+	if m.UpdateFunc == nil {
+		return nil, unexpected("ChecklistAPI.Update")
+	}
+	return m.UpdateFunc(ctx, id, name)
+}
+
+// Delete implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) Delete(ctx context.Context, id int) error {
+	m.record("Delete", id)
+	if m.DeleteFunc == nil {
+		return unexpected("ChecklistAPI.Delete")
+	}
+	return m.DeleteFunc(ctx, id)
+}
+
+// Archive implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) Archive(ctx context.Context, id int) (*checkvist.Checklist, error) {
+	m.record("Archive", id)
+	if m.ArchiveFunc == nil {
+		return nil, unexpected("ChecklistAPI.Archive")
+	}
+	return m.ArchiveFunc(ctx, id)
+}
+
+// Unarchive implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) Unarchive(ctx context.Context, id int) (*checkvist.Checklist, error) {
+	m.record("Unarchive", id)
+	if m.UnarchiveFunc == nil {
+		return nil, unexpected("ChecklistAPI.Unarchive")
+	}
+	return m.UnarchiveFunc(ctx, id)
+}
+
+// Clone implements checkvist.ChecklistAPI.
+func (m *ChecklistAPI) Clone(ctx context.Context, sourceID int, opts checkvist.CloneOptions) (*checkvist.Checklist, error) {
+	m.record("Clone", sourceID, opts)
+	if m.CloneFunc == nil {
+		return nil, unexpected("ChecklistAPI.Clone")
+	}
+	return m.CloneFunc(ctx, sourceID, opts)
+}
+
+// TaskAPI is a mock of checkvist.TaskAPI. Each method records the call
+// and delegates to the corresponding Func field, or returns an error
+// wrapping ErrUnexpectedCall if the field is nil.
+type TaskAPI struct {
+	Recorder
+	ListFunc          func(ctx context.Context) ([]checkvist.Task, error)
+	GetFunc           func(ctx context.Context, taskID int) (*checkvist.Task, error)
+	TreeFunc          func(ctx context.Context) (*checkvist.TaskTree, error)
+	CreateFunc        func(ctx context.Context, builder *checkvist.TaskBuilder) (*checkvist.Task, error)
+	CreateOutlineFunc func(ctx context.Context, nodes []*checkvist.OutlineNode, parentID int, position int) ([]checkvist.Task, error)
+	ImportFunc        func(ctx context.Context, outline string, opts checkvist.ImportOptions) ([]checkvist.Task, error)
+	UpdateFunc        func(ctx context.Context, taskID int, req checkvist.UpdateTaskRequest) (*checkvist.Task, error)
+	AddTagsFunc       func(ctx context.Context, taskID int, tags ...string) (*checkvist.Task, error)
+	RemoveTagsFunc    func(ctx context.Context, taskID int, tags ...string) (*checkvist.Task, error)
+	UpdateTagsFunc    func(ctx context.Context, taskID int, update checkvist.TagUpdate) (*checkvist.Task, error)
+	MoveFunc          func(ctx context.Context, taskID int, target checkvist.MoveTarget) (*checkvist.Task, error)
+	DeleteFunc        func(ctx context.Context, taskID int) error
+	CloseFunc         func(ctx context.Context, taskID int) (*checkvist.Task, error)
+	ReopenFunc        func(ctx context.Context, taskID int) (*checkvist.Task, error)
+	InvalidateFunc    func(ctx context.Context, taskID int) (*checkvist.Task, error)
+}
+
+var _ checkvist.TaskAPI = (*TaskAPI)(nil)
+
+// List implements checkvist.TaskAPI.
+func (m *TaskAPI) List(ctx context.Context) ([]checkvist.Task, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, unexpected("TaskAPI.List")
+	}
+	return m.ListFunc(ctx)
+}
+
+// Get implements checkvist.TaskAPI.
+func (m *TaskAPI) Get(ctx context.Context, taskID int) (*checkvist.Task, error) {
+	m.record("Get", taskID)
+	if m.GetFunc == nil {
+		return nil, unexpected("TaskAPI.Get")
+	}
+	return m.GetFunc(ctx, taskID)
+}
+
+// Tree implements checkvist.TaskAPI.
+func (m *TaskAPI) Tree(ctx context.Context) (*checkvist.TaskTree, error) {
+	m.record("Tree")
+	if m.TreeFunc == nil {
+		return nil, unexpected("TaskAPI.Tree")
+	}
+	return m.TreeFunc(ctx)
+}
+
+// Create implements checkvist.TaskAPI.
+func (m *TaskAPI) Create(ctx context.Context, builder *checkvist.TaskBuilder) (*checkvist.Task, error) {
+	m.record("Create", builder)
+	if m.CreateFunc == nil {
+		return nil, unexpected("TaskAPI.Create")
+	}
+	return m.CreateFunc(ctx, builder)
+}
+
+// CreateOutline implements checkvist.TaskAPI.
+func (m *TaskAPI) CreateOutline(ctx context.Context, nodes []*checkvist.OutlineNode, parentID int, position int) ([]checkvist.Task, error) {
+	m.record("CreateOutline", nodes, parentID, position)
+	if m.CreateOutlineFunc == nil {
+		return nil, unexpected("TaskAPI.CreateOutline")
+	}
+	return m.CreateOutlineFunc(ctx, nodes, parentID, position)
+}
+
+// Import implements checkvist.TaskAPI.
+func (m *TaskAPI) Import(ctx context.Context, outline string, opts checkvist.ImportOptions) ([]checkvist.Task, error) {
+	m.record("Import", outline, opts)
+	if m.ImportFunc == nil {
+		return nil, unexpected("TaskAPI.Import")
+	}
+	return m.ImportFunc(ctx, outline, opts)
+}
+
+// Update implements checkvist.TaskAPI.
+func (m *TaskAPI) Update(ctx context.Context, taskID int, req checkvist.UpdateTaskRequest) (*checkvist.Task, error) {
+	m.record("Update", taskID, req)
+	if m.UpdateFunc == nil {
+		return nil, unexpected("TaskAPI.Update")
+	}
+	return m.UpdateFunc(ctx, taskID, req)
+}
+
+// AddTags implements checkvist.TaskAPI.
+func (m *TaskAPI) AddTags(ctx context.Context, taskID int, tags ...string) (*checkvist.Task, error) {
+	m.record("AddTags", taskID, tags)
+	if m.AddTagsFunc == nil {
+		return nil, unexpected("TaskAPI.AddTags")
+	}
+	return m.AddTagsFunc(ctx, taskID, tags...)
+}
+
+// RemoveTags implements checkvist.TaskAPI.
+func (m *TaskAPI) RemoveTags(ctx context.Context, taskID int, tags ...string) (*checkvist.Task, error) {
+	m.record("RemoveTags", taskID, tags)
+	if m.RemoveTagsFunc == nil {
+		return nil, unexpected("TaskAPI.RemoveTags")
+	}
+	return m.RemoveTagsFunc(ctx, taskID, tags...)
+}
+
+// UpdateTags implements checkvist.TaskAPI.
+func (m *TaskAPI) UpdateTags(ctx context.Context, taskID int, update checkvist.TagUpdate) (*checkvist.Task, error) {
+	m.record("UpdateTags", taskID, update)
+	if m.UpdateTagsFunc == nil {
+		return nil, unexpected("TaskAPI.UpdateTags")
+	}
+	return m.UpdateTagsFunc(ctx, taskID, update)
+}
+
+// Move implements checkvist.TaskAPI.
+func (m *TaskAPI) Move(ctx context.Context, taskID int, target checkvist.MoveTarget) (*checkvist.Task, error) {
+	m.record("Move", taskID, target)
+	if m.MoveFunc == nil {
+		return nil, unexpected("TaskAPI.Move")
+	}
+	return m.MoveFunc(ctx, taskID, target)
+}
+
+// Delete implements checkvist.TaskAPI.
+func (m *TaskAPI) Delete(ctx context.Context, taskID int) error {
+	m.record("Delete", taskID)
+	if m.DeleteFunc == nil {
+		return unexpected("TaskAPI.Delete")
+	}
+	return m.DeleteFunc(ctx, taskID)
+}
+
+// Close implements checkvist.TaskAPI.
+func (m *TaskAPI) Close(ctx context.Context, taskID int) (*checkvist.Task, error) {
+	m.record("Close", taskID)
+	if m.CloseFunc == nil {
+		return nil, unexpected("TaskAPI.Close")
+	}
+	return m.CloseFunc(ctx, taskID)
+}
+
+// Reopen implements checkvist.TaskAPI.
+func (m *TaskAPI) Reopen(ctx context.Context, taskID int) (*checkvist.Task, error) {
+	m.record("Reopen", taskID)
+	if m.ReopenFunc == nil {
+		return nil, unexpected("TaskAPI.Reopen")
+	}
+	return m.ReopenFunc(ctx, taskID)
+}
+
+// Invalidate implements checkvist.TaskAPI.
+func (m *TaskAPI) Invalidate(ctx context.Context, taskID int) (*checkvist.Task, error) {
+	m.record("Invalidate", taskID)
+	if m.InvalidateFunc == nil {
+		return nil, unexpected("TaskAPI.Invalidate")
+	}
+	return m.InvalidateFunc(ctx, taskID)
+}
+
+// NoteAPI is a mock of checkvist.NoteAPI. Each method records the call
+// and delegates to the corresponding Func field, or returns an error
+// wrapping ErrUnexpectedCall if the field is nil.
+type NoteAPI struct {
+	Recorder
+	ListFunc   func(ctx context.Context) ([]checkvist.Note, error)
+	CreateFunc func(ctx context.Context, comment string) (*checkvist.Note, error)
+	UpdateFunc func(ctx context.Context, noteID int, comment string) (*checkvist.Note, error)
+	DeleteFunc func(ctx context.Context, noteID int) error
+}
+
+var _ checkvist.NoteAPI = (*NoteAPI)(nil)
+
+// List implements checkvist.NoteAPI.
+func (m *NoteAPI) List(ctx context.Context) ([]checkvist.Note, error) {
+	m.record("List")
+	if m.ListFunc == nil {
+		return nil, unexpected("NoteAPI.List")
+	}
+	return m.ListFunc(ctx)
+}
+
+// Create implements checkvist.NoteAPI.
+func (m *NoteAPI) Create(ctx context.Context, comment string) (*checkvist.Note, error) {
+	m.record("Create", comment)
+	if m.CreateFunc == nil {
+		return nil, unexpected("NoteAPI.Create")
+	}
+	return m.CreateFunc(ctx, comment)
+}
+
+// Update implements checkvist.NoteAPI.
+func (m *NoteAPI) Update(ctx context.Context, noteID int, comment string) (*checkvist.Note, error) {
+	m.record("Update", noteID, comment)
+	if m.UpdateFunc == nil {
+		return nil, unexpected("NoteAPI.Update")
+	}
+	return m.UpdateFunc(ctx, noteID, comment)
+}
+
+// Delete implements checkvist.NoteAPI.
+func (m *NoteAPI) Delete(ctx context.Context, noteID int) error {
+	m.record("Delete", noteID)
+	if m.DeleteFunc == nil {
+		return unexpected("NoteAPI.Delete")
+	}
+	return m.DeleteFunc(ctx, noteID)
+}
@@ -0,0 +1,21 @@
+module code.beautifulmachines.dev/jakoubek/checkvist-api/checkvistotel
+
+go 1.21
+
+require (
+	code.beautifulmachines.dev/jakoubek/checkvist-api v1.0.3
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel/metric v1.24.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+)
+
+// Build against the core module in this repository; the adapters use API
+// that is not in a tagged release yet.
+replace code.beautifulmachines.dev/jakoubek/checkvist-api => ../
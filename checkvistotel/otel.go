@@ -0,0 +1,67 @@
+// Package checkvistotel records OpenTelemetry spans for the requests of a
+// checkvist.Client.
+//
+// Usage:
+//
+//	client := checkvist.NewClient("user@example.com", "api-key",
+//		checkvist.WithObserver(checkvistotel.NewObserver(nil)),
+//	)
+package checkvistotel
+
+import (
+	"context"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer.
+const ScopeName = "code.beautifulmachines.dev/jakoubek/checkvist-api/checkvistotel"
+
+// Observer is a checkvist.Observer that records a client span per request attempt.
+type Observer struct {
+	tracer trace.Tracer
+}
+
+var _ checkvist.Observer = (*Observer)(nil)
+
+// NewObserver creates an observer using the given tracer provider.
+// If provider is nil, the global tracer provider is used.
+func NewObserver(provider trace.TracerProvider) *Observer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &Observer{tracer: provider.Tracer(ScopeName)}
+}
+
+// RequestStart starts a span named after the method and path template.
+func (o *Observer) RequestStart(ctx context.Context, event checkvist.RequestEvent) context.Context {
+	ctx, _ = o.tracer.Start(ctx, event.Method+" "+event.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", event.Method),
+			attribute.String("url.template", event.Path),
+			attribute.Int("checkvist.attempt", event.Attempt),
+		),
+	)
+	return ctx
+}
+
+// RequestEnd records the response status or error and ends the span.
+func (o *Observer) RequestEnd(ctx context.Context, event checkvist.RequestEvent) {
+	span := trace.SpanFromContext(ctx)
+	switch {
+	case event.Err != nil:
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	case event.StatusCode >= 400:
+		span.SetStatus(codes.Error, "")
+	}
+	if event.StatusCode != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", event.StatusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,48 @@
+package checkvistotel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestObserver(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	observer := NewObserver(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	event := checkvist.RequestEvent{Method: http.MethodGet, Path: "/checklists/{id}/tasks.json", Attempt: 1}
+	ctx := observer.RequestStart(context.Background(), event)
+	event.StatusCode = http.StatusServiceUnavailable
+	observer.RequestEnd(ctx, event)
+
+	failed := checkvist.RequestEvent{Method: http.MethodPost, Path: "/auth/login.json"}
+	ctx = observer.RequestStart(context.Background(), failed)
+	failed.Err = errors.New("connection refused")
+	observer.RequestEnd(ctx, failed)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /checklists/{id}/tasks.json" || span.Status().Code != codes.Error {
+		t.Errorf("unexpected span: %s %v", span.Name(), span.Status())
+	}
+	attrs := attribute.NewSet(span.Attributes()...)
+	if v, _ := attrs.Value("http.response.status_code"); v.AsInt64() != 503 {
+		t.Errorf("expected status code attribute, got %v", v)
+	}
+	if v, _ := attrs.Value("checkvist.attempt"); v.AsInt64() != 1 {
+		t.Errorf("expected attempt attribute, got %v", v)
+	}
+	if len(spans[1].Events()) != 1 || spans[1].Status().Description != "connection refused" {
+		t.Errorf("expected error to be recorded, got %+v", spans[1].Status())
+	}
+}
@@ -0,0 +1,102 @@
+// Package checkvistprom records Prometheus metrics for the requests of a
+// checkvist.Client.
+//
+// Usage:
+//
+//	observer := checkvistprom.NewObserver(checkvistprom.Options{})
+//	prometheus.MustRegister(observer)
+//
+//	client := checkvist.NewClient("user@example.com", "api-key",
+//		checkvist.WithObserver(observer),
+//	)
+package checkvistprom
+
+import (
+	"context"
+	"strconv"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options configures the metrics of an Observer.
+type Options struct {
+	// Namespace is the metric namespace. Defaults to "checkvist".
+	Namespace string
+	// Buckets are the histogram buckets in seconds. Defaults to prometheus.DefBuckets.
+	Buckets []float64
+	// ConstLabels are added to every metric, e.g. to distinguish clients.
+	ConstLabels prometheus.Labels
+}
+
+// Observer is a checkvist.Observer that records request durations and
+// retries. It is a prometheus.Collector and must be registered to be exported.
+//
+// The metrics are:
+//   - <namespace>_request_duration_seconds: histogram by method, path and status
+//   - <namespace>_request_retries_total: counter of retry attempts by method and path
+//
+// The status label is the HTTP status code, or "error" if no response was received.
+type Observer struct {
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+var (
+	_ checkvist.Observer   = (*Observer)(nil)
+	_ prometheus.Collector = (*Observer)(nil)
+)
+
+// NewObserver creates an observer with the given options.
+func NewObserver(opts Options) *Observer {
+	if opts.Namespace == "" {
+		opts.Namespace = "checkvist"
+	}
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+	return &Observer{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Duration of Checkvist API requests.",
+			Buckets:     opts.Buckets,
+			ConstLabels: opts.ConstLabels,
+		}, []string{"method", "path", "status"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_retries_total",
+			Help:        "Number of retried Checkvist API requests.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"method", "path"}),
+	}
+}
+
+// RequestStart counts retry attempts.
+func (o *Observer) RequestStart(ctx context.Context, event checkvist.RequestEvent) context.Context {
+	if event.Attempt > 0 {
+		o.retries.WithLabelValues(event.Method, event.Path).Inc()
+	}
+	return ctx
+}
+
+// RequestEnd records the request duration.
+func (o *Observer) RequestEnd(_ context.Context, event checkvist.RequestEvent) {
+	status := "error"
+	if event.StatusCode != 0 {
+		status = strconv.Itoa(event.StatusCode)
+	}
+	o.duration.WithLabelValues(event.Method, event.Path, status).Observe(event.Duration.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (o *Observer) Describe(ch chan<- *prometheus.Desc) {
+	o.duration.Describe(ch)
+	o.retries.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (o *Observer) Collect(ch chan<- prometheus.Metric) {
+	o.duration.Collect(ch)
+	o.retries.Collect(ch)
+}
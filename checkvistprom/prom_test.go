@@ -0,0 +1,44 @@
+package checkvistprom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserver(t *testing.T) {
+	observer := NewObserver(Options{})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(observer)
+
+	ctx := context.Background()
+	for attempt, status := range []int{http.StatusBadGateway, http.StatusOK} {
+		event := checkvist.RequestEvent{Method: http.MethodGet, Path: "/checklists.json", Attempt: attempt}
+		observer.RequestStart(ctx, event)
+		event.StatusCode = status
+		event.Duration = 20 * time.Millisecond
+		observer.RequestEnd(ctx, event)
+	}
+	failed := checkvist.RequestEvent{Method: http.MethodPost, Path: "/auth/login.json", Err: errors.New("timeout")}
+	observer.RequestEnd(ctx, failed)
+
+	if n := testutil.CollectAndCount(observer, "checkvist_request_duration_seconds"); n != 3 {
+		t.Errorf("expected 3 duration series, got %d", n)
+	}
+
+	expected := `
+# HELP checkvist_request_retries_total Number of retried Checkvist API requests.
+# TYPE checkvist_request_retries_total counter
+checkvist_request_retries_total{method="GET",path="/checklists.json"} 1
+`
+	if err := testutil.CollectAndCompare(observer, strings.NewReader(expected), "checkvist_request_retries_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,304 @@
+// Package checkvisttest provides helpers for testing code that uses the
+// checkvist client without talking to the real Checkvist API.
+//
+// Server is a stateful in-memory fake of the API with fault injection:
+//
+//	server := checkvisttest.NewServer()
+//	defer server.Close()
+//	list := server.AddChecklist("Inbox")
+//	client := server.Client()
+//
+// A Recorder captures real API interactions into a cassette file, and a
+// Replayer serves them back in later test runs:
+//
+//	// Record once against the real API
+//	rec := checkvisttest.NewRecorder("testdata/list.json", nil)
+//	client := checkvist.NewClient(user, key, checkvist.WithHTTPClient(rec.Client()))
+//	...
+//	rec.Save()
+//
+//	// Replay in tests
+//	rep := checkvisttest.NewReplayer(t, "testdata/list.json")
+//	client := checkvist.NewClient(user, "key", checkvist.WithHTTPClient(rep.Client()))
+package checkvisttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// cassette.go contains the recording and replaying HTTP transports.
+
+// Redacted replaces credentials in recorded cassettes.
+const Redacted = "REDACTED"
+
+// StatusNoInteraction is the status code of the response a Replayer returns
+// for requests that match no recorded interaction. The client neither retries
+// it nor maps it to one of its sentinel errors.
+const StatusNoInteraction = http.StatusTeapot
+
+// Cassette is a sequence of recorded HTTP interactions.
+type Cassette struct {
+	// Interactions are the recorded interactions in the order they occurred.
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request used to match it during replay.
+// Request headers are not recorded, so tokens never end up in a cassette.
+type RecordedRequest struct {
+	// Method is the HTTP method.
+	Method string `json:"method"`
+	// Path is the URL path including the query string, relative to the host.
+	Path string `json:"path"`
+	// Body is the scrubbed request body.
+	Body string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	// StatusCode is the HTTP status code.
+	StatusCode int `json:"status_code"`
+	// Header contains the response headers, without cookies.
+	Header http.Header `json:"header,omitempty"`
+	// Body is the scrubbed response body.
+	Body string `json:"body,omitempty"`
+}
+
+// LoadCassette reads a cassette file.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("decoding cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to path, creating parent directories as needed.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating cassette directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	return nil
+}
+
+// Recorder is an http.RoundTripper that forwards requests to a real
+// transport and records the interactions, with credentials scrubbed.
+type Recorder struct {
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder creates a recorder that saves to path and sends requests via
+// transport. If transport is nil, http.DefaultTransport is used.
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{path: path, transport: transport}
+}
+
+// Client returns an HTTP client using the recorder, for checkvist.WithHTTPClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("checkvisttest: reading response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       scrub(string(body)),
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the recorded interactions to the recorder's cassette file.
+func (r *Recorder) Save() error {
+	return r.Cassette().Save(r.path)
+}
+
+// Replayer is an http.RoundTripper that serves responses from a cassette
+// without network access. Each recorded interaction is served once, in
+// recorded order among interactions with the same method, path and body.
+// Requests without a matching interaction fail the test, so that a miss is
+// reported even if the code under test handles the resulting error. The
+// transport answers them with a StatusNoInteraction response, which the
+// client returns as an *checkvist.APIError without retrying.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	tb           testing.TB
+}
+
+// NewReplayer creates a replayer serving the cassette file at path and
+// reporting unmatched requests to t. It stops the test if the cassette
+// cannot be loaded.
+func NewReplayer(t testing.TB, path string) *Replayer {
+	t.Helper()
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("checkvisttest: %v", err)
+	}
+	return NewCassetteReplayer(t, cassette)
+}
+
+// NewCassetteReplayer creates a replayer serving the given cassette and
+// reporting unmatched requests to t.
+func NewCassetteReplayer(t testing.TB, cassette *Cassette) *Replayer {
+	return &Replayer{
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+		tb:           t,
+	}
+}
+
+// Client returns an HTTP client using the replayer, for checkvist.WithHTTPClient.
+func (r *Replayer) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request != recorded {
+			continue
+		}
+		r.used[i] = true
+		return replayResponse(req, interaction.Response), nil
+	}
+
+	message := fmt.Sprintf("checkvisttest: no recorded interaction for %s %s (body %q)", recorded.Method, recorded.Path, recorded.Body)
+	r.tb.Errorf("%s", message)
+	return replayResponse(req, RecordedResponse{
+		StatusCode: StatusNoInteraction,
+		Header:     http.Header{"Content-Type": {"text/plain; charset=utf-8"}},
+		Body:       message,
+	}), nil
+}
+
+// replayResponse builds the HTTP response to req from a recorded response.
+func replayResponse(req *http.Request, resp RecordedResponse) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        resp.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+// Unused returns the recorded interactions that have not been served yet.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, interaction := range r.interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// recordRequest captures the matchable parts of a request, restoring its body.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   scrub(req.URL.RequestURI()),
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return recorded, fmt.Errorf("checkvisttest: reading request: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		recorded.Body = scrub(string(body))
+	}
+	return recorded, nil
+}
+
+var (
+	scrubForm = regexp.MustCompile(`\b(remote_key|old_token|totp)=[^&\s]*`)
+	scrubJSON = regexp.MustCompile(`("(?:token|remote_key|old_token)"\s*:\s*)"[^"]*"`)
+)
+
+// scrub replaces credentials in form-encoded and JSON content.
+func scrub(s string) string {
+	s = scrubForm.ReplaceAllString(s, "${1}="+Redacted)
+	s = scrubJSON.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+	return s
+}
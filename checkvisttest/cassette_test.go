@@ -0,0 +1,173 @@
+package checkvisttest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+func TestRecordReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "secret-token"})
+		case "/checklists.json":
+			if r.Method == http.MethodPost {
+				json.NewEncoder(w).Encode(checkvist.Checklist{ID: 2, Name: "New"})
+				return
+			}
+			json.NewEncoder(w).Encode([]checkvist.Checklist{{ID: 1, Name: "Recorded"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "checklists.json")
+	ctx := context.Background()
+
+	recorder := NewRecorder(path, nil)
+	client := checkvist.NewClient("user@example.com", "secret-key",
+		checkvist.WithBaseURL(server.URL),
+		checkvist.WithHTTPClient(recorder.Client()),
+	)
+	if _, err := client.Checklists().List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Checklists().Create(ctx, "New"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, secret := range []string{"secret-key", "secret-token"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("expected %q to be scrubbed from cassette:\n%s", secret, data)
+		}
+	}
+
+	misses := &missRecorder{TB: t}
+	replayer := NewReplayer(misses, path)
+	replayed := checkvist.NewClient("user@example.com", "other-key",
+		checkvist.WithBaseURL("http://checkvist.invalid"),
+		checkvist.WithHTTPClient(replayer.Client()),
+	)
+	checklists, err := replayed.Checklists().List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checklists) != 1 || checklists[0].Name != "Recorded" {
+		t.Errorf("expected recorded checklists, got %+v", checklists)
+	}
+	if len(replayer.Unused()) != 1 {
+		t.Errorf("expected create interaction to be unused, got %d", len(replayer.Unused()))
+	}
+
+	_, err = replayed.Checklists().Create(ctx, "Different")
+	var apiErr *checkvist.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != StatusNoInteraction {
+		t.Errorf("expected StatusNoInteraction for unrecorded body, got %v", err)
+	}
+	if len(misses.errors) != 1 || !strings.Contains(misses.errors[0], "POST /checklists.json") {
+		t.Errorf("expected the unrecorded request to fail the test, got %q", misses.errors)
+	}
+}
+
+// missRecorder records the errors reported to it instead of failing the test.
+type missRecorder struct {
+	testing.TB
+	errors []string
+}
+
+func (m *missRecorder) Errorf(format string, args ...any) {
+	m.errors = append(m.errors, fmt.Sprintf(format, args...))
+}
+
+func TestReplayer_FailsMissesWithoutRetry(t *testing.T) {
+	misses := &missRecorder{TB: t}
+	replayer := NewCassetteReplayer(misses, &Cassette{})
+	var requests int
+	client := checkvist.NewClient("user@example.com", "key",
+		checkvist.WithBaseURL("http://checkvist.invalid"),
+		checkvist.WithHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return replayer.RoundTrip(req)
+		})}),
+	)
+
+	start := time.Now()
+	_, err := client.Checklists().List(context.Background())
+	if err == nil {
+		t.Fatal("expected error for unrecorded request")
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request without retries, got %d", requests)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected miss to fail fast, took %v", elapsed)
+	}
+	if len(misses.errors) != 1 || !strings.Contains(misses.errors[0], "POST /auth/login.json") {
+		t.Errorf("expected the login request to be reported, got %q", misses.errors)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestReplayer_ServesEachInteractionOnce(t *testing.T) {
+	misses := &missRecorder{TB: t}
+	replayer := NewCassetteReplayer(misses, &Cassette{Interactions: []Interaction{
+		{Request: RecordedRequest{Method: "GET", Path: "/checklists.json"}, Response: RecordedResponse{StatusCode: 500}},
+		{Request: RecordedRequest{Method: "GET", Path: "/checklists.json"}, Response: RecordedResponse{StatusCode: 200, Body: "[]"}},
+	}})
+
+	var statuses []int
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://checkvist.invalid/checklists.json", nil)
+		resp, err := replayer.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if len(statuses) != 3 || statuses[0] != 500 || statuses[1] != 200 || statuses[2] != StatusNoInteraction {
+		t.Errorf("expected recorded order [500 200 %d], got %v", StatusNoInteraction, statuses)
+	}
+	if len(misses.errors) != 1 {
+		t.Errorf("expected the third request to be reported, got %q", misses.errors)
+	}
+}
+
+func TestScrub(t *testing.T) {
+	tests := map[string]string{
+		"remote_key=abc&username=u":         "remote_key=REDACTED&username=u",
+		"old_token=abc":                     "old_token=REDACTED",
+		`{"token": "abc", "name": "x"}`:     `{"token": "REDACTED", "name": "x"}`,
+		"/auth/login.json?version=2&totp=1": "/auth/login.json?version=2&totp=REDACTED",
+	}
+	for input, expected := range tests {
+		if got := scrub(input); got != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, got)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package checkvisttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+// server.go contains Server, a stateful in-memory fake of the Checkvist API.
+
+// Default credentials accepted by a Server.
+const (
+	DefaultUsername  = "user@example.com"
+	DefaultRemoteKey = "api-key"
+)
+
+// Server is an in-memory fake of the Checkvist API for tests. It implements
+// authentication, checklists, tasks and notes statefully, so that code under
+// test can create, modify and read back data through a real checkvist.Client.
+//
+// Faults such as latency, rate limiting, server errors and dropped
+// connections can be injected with InjectFault to exercise retry logic.
+//
+// A Server is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, for checkvist.WithBaseURL.
+	URL string
+
+	srv       *httptest.Server
+	username  string
+	remoteKey string
+	totp      string
+	now       func() time.Time
+
+	mu         sync.Mutex
+	nextID     int
+	tokens     map[string]bool
+	checklists map[int]*checkvist.Checklist
+	tasks      map[int]*checkvist.Task
+	notes      map[int]*checkvist.Note
+	faults     []*Fault
+	requests   []RecordedRequest
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithCredentials sets the username and remote key accepted by the server.
+// The defaults are DefaultUsername and DefaultRemoteKey.
+func WithCredentials(username, remoteKey string) ServerOption {
+	return func(s *Server) {
+		s.username = username
+		s.remoteKey = remoteKey
+	}
+}
+
+// WithTOTP requires the given 2FA code for logins.
+func WithTOTP(code string) ServerOption {
+	return func(s *Server) {
+		s.totp = code
+	}
+}
+
+// WithClock sets the function used for timestamps. The default is time.Now.
+func WithClock(now func() time.Time) ServerOption {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// NewServer starts a new fake server with no data. Call Close when done.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		username:   DefaultUsername,
+		remoteKey:  DefaultRemoteKey,
+		now:        time.Now,
+		tokens:     make(map[string]bool),
+		checklists: make(map[int]*checkvist.Checklist),
+		tasks:      make(map[int]*checkvist.Task),
+		notes:      make(map[int]*checkvist.Note),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a checkvist.Client configured for the server with the
+// server's credentials and short retry delays. Additional options are
+// applied after the defaults.
+func (s *Server) Client(opts ...checkvist.Option) *checkvist.Client {
+	defaults := []checkvist.Option{
+		checkvist.WithBaseURL(s.URL),
+		checkvist.WithRetryConfig(checkvist.RetryConfig{
+			MaxRetries: 3,
+			BaseDelay:  time.Millisecond,
+			MaxDelay:   10 * time.Millisecond,
+		}),
+	}
+	return checkvist.NewClient(s.username, s.remoteKey, append(defaults, opts...)...)
+}
+
+// Fault describes an error the server injects into matching requests.
+// Latency is applied first; then the connection is dropped if Drop is set,
+// or StatusCode is returned if it is non-zero.
+type Fault struct {
+	// Method restricts the fault to an HTTP method. Empty matches any method.
+	Method string
+	// Path restricts the fault to URL paths matching a path.Match pattern,
+	// e.g. "/checklists/*/tasks.json". Empty matches any path.
+	Path string
+	// Times is the number of requests the fault applies to. Zero means every
+	// matching request until the fault is cleared.
+	Times int
+	// Latency delays the response.
+	Latency time.Duration
+	// StatusCode is returned instead of handling the request, e.g. 429 or 503.
+	StatusCode int
+	// RetryAfter sets the Retry-After header of the injected response.
+	RetryAfter time.Duration
+	// Drop closes the connection without sending a response.
+	Drop bool
+
+	hits int
+}
+
+// InjectFault adds a fault. Faults are checked in the order they were added
+// and the first matching one applies.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// ExpireTokens invalidates all issued tokens, so that the next request with
+// an old token is rejected with HTTP 401.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]bool)
+}
+
+// Requests returns the requests received so far, including failed ones.
+// Credentials in request bodies are scrubbed.
+func (s *Server) Requests() []RecordedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]RecordedRequest(nil), s.requests...)
+}
+
+// matchFault returns the fault for the request, if any, and counts the hit.
+// Callers must hold mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if fault.Path != "" {
+			if ok, _ := path.Match(fault.Path, r.URL.Path); !ok {
+				continue
+			}
+		}
+		fault.hits++
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+		}
+		return fault
+	}
+	return nil
+}
+
+// applyFault injects the fault into the response. It reports whether the
+// request has been fully handled.
+func applyFault(w http.ResponseWriter, fault *Fault) bool {
+	if fault.Latency > 0 {
+		time.Sleep(fault.Latency)
+	}
+	if fault.Drop {
+		if hj, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hj.Hijack(); err == nil {
+				conn.Close()
+				return true
+			}
+		}
+		panic(http.ErrAbortHandler)
+	}
+	if fault.StatusCode != 0 {
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int((fault.RetryAfter+time.Second-1)/time.Second)))
+		}
+		writeError(w, fault.StatusCode, http.StatusText(fault.StatusCode))
+		return true
+	}
+	return false
+}
+
+// serveHTTP logs the request, applies faults and dispatches it.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	recorded, err := recordRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, recorded)
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if fault != nil && applyFault(w, fault) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	segments := strings.Split(strings.TrimSuffix(strings.Trim(r.URL.Path, "/"), ".json"), "/")
+	if segments[0] == "auth" && len(segments) == 2 {
+		s.serveAuth(w, r, segments[1])
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Unauthenticated")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, body := s.route(r, segments)
+	if status >= 400 {
+		msg, _ := body.(string)
+		writeError(w, status, msg)
+		return
+	}
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// serveAuth handles the authentication endpoints.
+func (s *Server) serveAuth(w http.ResponseWriter, r *http.Request, action string) {
+	r.ParseForm()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case action == "login" && r.Method == http.MethodPost:
+		if r.Form.Get("username") != s.username || r.Form.Get("remote_key") != s.remoteKey {
+			writeError(w, http.StatusUnauthorized, "Invalid credentials")
+			return
+		}
+		if s.totp != "" && r.Form.Get("totp") != s.totp {
+			writeError(w, http.StatusUnauthorized, "Invalid 2FA code")
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": s.issueToken()})
+	case action == "refresh_token" && r.Method == http.MethodPost:
+		if !s.tokens[r.Form.Get("old_token")] {
+			writeError(w, http.StatusUnauthorized, "Invalid token")
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": s.issueToken()})
+	case action == "curr_user" && r.Method == http.MethodGet:
+		if !s.tokens[requestToken(r)] {
+			writeError(w, http.StatusUnauthorized, "Unauthenticated")
+			return
+		}
+		name, _, _ := strings.Cut(s.username, "@")
+		json.NewEncoder(w).Encode(checkvist.User{ID: 1, Username: name, Email: s.username})
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+// issueToken creates a new valid token. Callers must hold mu.
+func (s *Server) issueToken() string {
+	s.nextID++
+	token := fmt.Sprintf("token-%d", s.nextID)
+	s.tokens[token] = true
+	return token
+}
+
+// authorized reports whether the request carries a valid token.
+func (s *Server) authorized(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[requestToken(r)]
+}
+
+// requestToken returns the token from the X-Client-Token header or the
+// token query parameter.
+func requestToken(r *http.Request) string {
+	if token := r.Header.Get("X-Client-Token"); token != "" {
+		return token
+	}
+	return r.URL.Query().Get("token")
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+// decodeBody decodes a JSON request body into v.
+func decodeBody(r *http.Request, v any) error {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,596 @@
+package checkvisttest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+// server_api.go contains the checklist, task and note endpoints of Server
+// and the helpers for seeding and inspecting its data.
+
+// route dispatches an authenticated API request. It returns the status code
+// and either the response body or, for errors, the error message.
+// Callers must hold mu.
+func (s *Server) route(r *http.Request, segments []string) (int, any) {
+	if segments[0] != "checklists" || len(segments) > 6 {
+		return http.StatusNotFound, "Not found"
+	}
+	if len(segments) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			return s.listChecklists(r.URL.Query().Get("archived") == "true")
+		case http.MethodPost:
+			return s.createChecklist(r)
+		}
+		return http.StatusMethodNotAllowed, "Method not allowed"
+	}
+
+	checklist := s.checklists[atoi(segments[1])]
+	if checklist == nil {
+		return http.StatusNotFound, "Checklist not found"
+	}
+	if len(segments) == 2 {
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, s.checklistOut(checklist)
+		case http.MethodPut:
+			return s.updateChecklist(r, checklist)
+		case http.MethodDelete:
+			return s.deleteChecklist(checklist)
+		}
+		return http.StatusMethodNotAllowed, "Method not allowed"
+	}
+	if segments[2] != "tasks" {
+		return http.StatusNotFound, "Not found"
+	}
+	if len(segments) == 3 {
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, s.listTasks(checklist.ID)
+		case http.MethodPost:
+			return s.createTask(r, checklist)
+		}
+		return http.StatusMethodNotAllowed, "Method not allowed"
+	}
+
+	task := s.tasks[atoi(segments[3])]
+	if task == nil || task.ChecklistID != checklist.ID {
+		return http.StatusNotFound, "Task not found"
+	}
+	if len(segments) == 4 {
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, s.taskOut(task)
+		case http.MethodPut:
+			return s.updateTask(r, task)
+		case http.MethodDelete:
+			return s.deleteTask(task)
+		}
+		return http.StatusMethodNotAllowed, "Method not allowed"
+	}
+
+	switch action := segments[4]; {
+	case len(segments) == 5 && (action == "close" || action == "reopen" || action == "invalidate"):
+		if r.Method != http.MethodPost {
+			return http.StatusMethodNotAllowed, "Method not allowed"
+		}
+		return s.setStatus(task, action)
+	case action == "comments" && len(segments) == 5:
+		switch r.Method {
+		case http.MethodGet:
+			return http.StatusOK, s.listNotes(task.ID)
+		case http.MethodPost:
+			return s.createNote(r, task)
+		}
+		return http.StatusMethodNotAllowed, "Method not allowed"
+	case action == "comments":
+		note := s.notes[atoi(segments[5])]
+		if note == nil || note.TaskID != task.ID {
+			return http.StatusNotFound, "Note not found"
+		}
+		switch r.Method {
+		case http.MethodPut:
+			return s.updateNote(r, note)
+		case http.MethodDelete:
+			return s.deleteNote(task, note)
+		}
+		return http.StatusMethodNotAllowed, "Method not allowed"
+	}
+	return http.StatusNotFound, "Not found"
+}
+
+// atoi parses an ID path segment, returning zero if it is invalid.
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// id returns a new unique ID. Callers must hold mu.
+func (s *Server) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+// timestamp returns the current time for UpdatedAt and CreatedAt fields.
+func (s *Server) timestamp() checkvist.APITime {
+	return checkvist.NewAPITime(s.now().UTC().Truncate(time.Second))
+}
+
+// Checklists
+
+func (s *Server) listChecklists(archived bool) (int, any) {
+	result := []checkvist.Checklist{}
+	for _, cl := range s.sortedChecklists() {
+		if cl.Archived == archived {
+			result = append(result, s.checklistOut(cl))
+		}
+	}
+	return http.StatusOK, result
+}
+
+func (s *Server) createChecklist(r *http.Request) (int, any) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	if body.Name == "" {
+		return http.StatusBadRequest, "Name is required"
+	}
+	return http.StatusOK, s.checklistOut(s.addChecklist(body.Name))
+}
+
+func (s *Server) updateChecklist(r *http.Request, cl *checkvist.Checklist) (int, any) {
+	var body struct {
+		Name     *string `json:"name"`
+		Archived *bool   `json:"archived"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	if body.Name != nil {
+		if *body.Name == "" {
+			return http.StatusBadRequest, "Name is required"
+		}
+		cl.Name = *body.Name
+	}
+	if body.Archived != nil {
+		cl.Archived = *body.Archived
+	}
+	cl.UpdatedAt = s.timestamp()
+	return http.StatusOK, s.checklistOut(cl)
+}
+
+func (s *Server) deleteChecklist(cl *checkvist.Checklist) (int, any) {
+	for _, task := range s.tasks {
+		if task.ChecklistID == cl.ID {
+			s.removeTask(task)
+		}
+	}
+	delete(s.checklists, cl.ID)
+	return http.StatusOK, s.checklistOut(cl)
+}
+
+// addChecklist creates a checklist. Callers must hold mu.
+func (s *Server) addChecklist(name string) *checkvist.Checklist {
+	cl := &checkvist.Checklist{ID: s.id(), Name: name, UpdatedAt: s.timestamp()}
+	s.checklists[cl.ID] = cl
+	return cl
+}
+
+// checklistOut returns a copy of the checklist with task counts filled in.
+func (s *Server) checklistOut(cl *checkvist.Checklist) checkvist.Checklist {
+	out := *cl
+	out.Tags = nil
+	out.TaskCount, out.TaskCompleted = 0, 0
+	for _, task := range s.tasks {
+		if task.ChecklistID == cl.ID {
+			out.TaskCount++
+			if task.Status != checkvist.StatusOpen {
+				out.TaskCompleted++
+			}
+		}
+	}
+	return out
+}
+
+func (s *Server) sortedChecklists() []*checkvist.Checklist {
+	result := make([]*checkvist.Checklist, 0, len(s.checklists))
+	for _, cl := range s.checklists {
+		result = append(result, cl)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// Tasks
+
+// listTasks returns the tasks of a checklist in tree order.
+func (s *Server) listTasks(checklistID int) []checkvist.Task {
+	result := []checkvist.Task{}
+	var walk func(parentID int)
+	walk = func(parentID int) {
+		for _, task := range s.children(checklistID, parentID) {
+			result = append(result, s.taskOut(task))
+			walk(task.ID)
+		}
+	}
+	walk(0)
+	return result
+}
+
+func (s *Server) createTask(r *http.Request, cl *checkvist.Checklist) (int, any) {
+	var body struct {
+		Task checkvist.CreateTaskRequest `json:"task"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	req := body.Task
+	if req.Content == "" {
+		return http.StatusBadRequest, "Content is required"
+	}
+	if req.ParentID != 0 {
+		if parent := s.tasks[req.ParentID]; parent == nil || parent.ChecklistID != cl.ID {
+			return http.StatusBadRequest, "Parent task not found"
+		}
+	}
+
+	task := s.addTask(cl.ID, checkvist.Task{
+		ParentID:   req.ParentID,
+		Position:   req.Position,
+		Content:    req.Content,
+		Priority:   req.Priority,
+		TagsAsText: checkvist.ParseTags(req.Tags).String(),
+		DueDateRaw: s.resolveDue(req.Due),
+	})
+	return http.StatusOK, s.taskOut(task)
+}
+
+func (s *Server) updateTask(r *http.Request, task *checkvist.Task) (int, any) {
+	var body struct {
+		Task checkvist.UpdateTaskRequest `json:"task"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	req := body.Task
+
+	parentID, position := task.ParentID, task.Position
+	if req.ParentID != nil {
+		parentID = *req.ParentID
+		if parentID != 0 {
+			parent := s.tasks[parentID]
+			if parent == nil || parent.ChecklistID != task.ChecklistID {
+				return http.StatusBadRequest, "Parent task not found"
+			}
+			if s.isDescendant(parent, task.ID) {
+				return http.StatusBadRequest, "Cannot move a task into its own subtree"
+			}
+		}
+	}
+	if req.Position != nil {
+		position = *req.Position
+	}
+	if req.Content != nil {
+		if *req.Content == "" {
+			return http.StatusBadRequest, "Content is required"
+		}
+		task.Content = *req.Content
+	}
+	if req.Due != nil {
+		task.DueDateRaw = s.resolveDue(*req.Due)
+	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.Tags != nil {
+		task.TagsAsText = checkvist.ParseTags(*req.Tags).String()
+	}
+	if parentID != task.ParentID || position != task.Position {
+		oldParent := task.ParentID
+		s.place(task, parentID, position)
+		if oldParent != parentID {
+			s.renumber(task.ChecklistID, oldParent)
+		}
+	}
+
+	s.touch(task)
+	return http.StatusOK, s.taskOut(task)
+}
+
+func (s *Server) deleteTask(task *checkvist.Task) (int, any) {
+	out := s.taskOut(task)
+	s.removeTask(task)
+	s.renumber(task.ChecklistID, task.ParentID)
+	if cl := s.checklists[task.ChecklistID]; cl != nil {
+		cl.UpdatedAt = s.timestamp()
+	}
+	return http.StatusOK, out
+}
+
+// setStatus closes, reopens or invalidates a task. Closing and invalidating
+// also apply to the open subtasks; reopening applies to the task only.
+func (s *Server) setStatus(task *checkvist.Task, action string) (int, any) {
+	status := map[string]checkvist.TaskStatus{
+		"close":      checkvist.StatusClosed,
+		"reopen":     checkvist.StatusOpen,
+		"invalidate": checkvist.StatusInvalidated,
+	}[action]
+
+	task.Status = status
+	s.touch(task)
+	changed := []checkvist.Task{s.taskOut(task)}
+	if status != checkvist.StatusOpen {
+		for _, sub := range s.descendants(task) {
+			if sub.Status == checkvist.StatusOpen {
+				sub.Status = status
+				s.touch(sub)
+				changed = append(changed, s.taskOut(sub))
+			}
+		}
+	}
+	return http.StatusOK, changed
+}
+
+// addTask inserts a task into a checklist. Callers must hold mu.
+func (s *Server) addTask(checklistID int, task checkvist.Task) *checkvist.Task {
+	t := &task
+	t.ID = s.id()
+	t.ChecklistID = checklistID
+	t.CreatedAt = s.timestamp()
+	t.ChildIDs, t.Notes, t.Tags, t.DueDate = nil, nil, nil, nil
+	s.tasks[t.ID] = t
+	s.place(t, t.ParentID, t.Position)
+	s.touch(t)
+	return t
+}
+
+// place moves the task under parentID at the 1-based position, appending it
+// if position is out of range, and renumbers the siblings.
+func (s *Server) place(task *checkvist.Task, parentID, position int) {
+	var siblings []*checkvist.Task
+	for _, sibling := range s.children(task.ChecklistID, parentID) {
+		if sibling != task {
+			siblings = append(siblings, sibling)
+		}
+	}
+	index := len(siblings)
+	if position >= 1 && position <= len(siblings) {
+		index = position - 1
+	}
+	siblings = append(siblings[:index], append([]*checkvist.Task{task}, siblings[index:]...)...)
+
+	task.ParentID = parentID
+	for i, sibling := range siblings {
+		sibling.Position = i + 1
+	}
+}
+
+// renumber assigns contiguous positions to the children of parentID.
+func (s *Server) renumber(checklistID, parentID int) {
+	for i, child := range s.children(checklistID, parentID) {
+		child.Position = i + 1
+	}
+}
+
+// children returns the direct children of parentID ordered by position.
+func (s *Server) children(checklistID, parentID int) []*checkvist.Task {
+	var result []*checkvist.Task
+	for _, task := range s.tasks {
+		if task.ChecklistID == checklistID && task.ParentID == parentID {
+			result = append(result, task)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Position != result[j].Position {
+			return result[i].Position < result[j].Position
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// descendants returns all tasks below the task in tree order.
+func (s *Server) descendants(task *checkvist.Task) []*checkvist.Task {
+	var result []*checkvist.Task
+	for _, child := range s.children(task.ChecklistID, task.ID) {
+		result = append(result, child)
+		result = append(result, s.descendants(child)...)
+	}
+	return result
+}
+
+// isDescendant reports whether task is ancestorID or lies below it.
+func (s *Server) isDescendant(task *checkvist.Task, ancestorID int) bool {
+	for t := task; t != nil; t = s.tasks[t.ParentID] {
+		if t.ID == ancestorID {
+			return true
+		}
+		if t.ParentID == 0 {
+			break
+		}
+	}
+	return false
+}
+
+// removeTask deletes the task, its subtasks and their notes.
+func (s *Server) removeTask(task *checkvist.Task) {
+	for _, sub := range append(s.descendants(task), task) {
+		for id, note := range s.notes {
+			if note.TaskID == sub.ID {
+				delete(s.notes, id)
+			}
+		}
+		delete(s.tasks, sub.ID)
+	}
+}
+
+// touch updates the timestamps of the task and its checklist.
+func (s *Server) touch(task *checkvist.Task) {
+	task.UpdatedAt = s.timestamp()
+	if cl := s.checklists[task.ChecklistID]; cl != nil {
+		cl.UpdatedAt = task.UpdatedAt
+	}
+}
+
+// taskOut returns a copy of the task with its child IDs filled in.
+func (s *Server) taskOut(task *checkvist.Task) checkvist.Task {
+	out := *task
+	out.Tags, out.DueDate, out.Notes, out.ChildIDs = nil, nil, nil, nil
+	for _, child := range s.children(task.ChecklistID, task.ID) {
+		out.ChildIDs = append(out.ChildIDs, child.ID)
+	}
+	return out
+}
+
+// resolveDue converts the due date syntax understood by the fake into the
+// API's date format. "today", "tomorrow" and ISO dates are supported; other
+// values are stored unchanged.
+func (s *Server) resolveDue(due string) string {
+	today := s.now()
+	switch strings.ToLower(strings.TrimSpace(due)) {
+	case "today":
+		return today.Format("2006/01/02")
+	case "tomorrow":
+		return today.AddDate(0, 0, 1).Format("2006/01/02")
+	}
+	if t, err := time.Parse("2006-01-02", due); err == nil {
+		return t.Format("2006/01/02")
+	}
+	return due
+}
+
+// Notes
+
+func (s *Server) listNotes(taskID int) []checkvist.Note {
+	result := []checkvist.Note{}
+	for _, note := range s.notes {
+		if note.TaskID == taskID {
+			result = append(result, *note)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// noteBody is the request body of note create and update requests.
+type noteBody struct {
+	Comment struct {
+		Comment string `json:"comment"`
+	} `json:"comment"`
+}
+
+func (s *Server) createNote(r *http.Request, task *checkvist.Task) (int, any) {
+	var body noteBody
+	if err := decodeBody(r, &body); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	if body.Comment.Comment == "" {
+		return http.StatusBadRequest, "Comment is required"
+	}
+	return http.StatusOK, *s.addNote(task, body.Comment.Comment)
+}
+
+func (s *Server) updateNote(r *http.Request, note *checkvist.Note) (int, any) {
+	var body noteBody
+	if err := decodeBody(r, &body); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	if body.Comment.Comment == "" {
+		return http.StatusBadRequest, "Comment is required"
+	}
+	note.Comment = body.Comment.Comment
+	note.UpdatedAt = s.timestamp()
+	return http.StatusOK, *note
+}
+
+func (s *Server) deleteNote(task *checkvist.Task, note *checkvist.Note) (int, any) {
+	delete(s.notes, note.ID)
+	task.CommentsCount--
+	return http.StatusOK, *note
+}
+
+// addNote adds a note to the task. Callers must hold mu.
+func (s *Server) addNote(task *checkvist.Task, comment string) *checkvist.Note {
+	note := &checkvist.Note{
+		ID:        s.id(),
+		TaskID:    task.ID,
+		Comment:   comment,
+		CreatedAt: s.timestamp(),
+		UpdatedAt: s.timestamp(),
+	}
+	s.notes[note.ID] = note
+	task.CommentsCount++
+	return note
+}
+
+// Seeding and inspection
+
+// AddChecklist creates a checklist directly in the server's state and
+// returns it, e.g. to seed data for a test.
+func (s *Server) AddChecklist(name string) checkvist.Checklist {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checklistOut(s.addChecklist(name))
+}
+
+// AddTask creates a task in the checklist directly in the server's state.
+// Content, ParentID, Position, Status, Priority, TagsAsText and DueDateRaw
+// are taken from task; the other fields are assigned by the server.
+// AddTask panics if the checklist or parent task does not exist.
+func (s *Server) AddTask(checklistID int, task checkvist.Task) checkvist.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checklists[checklistID] == nil {
+		panic(fmt.Sprintf("checkvisttest: checklist %d not found", checklistID))
+	}
+	if task.ParentID != 0 && s.tasks[task.ParentID] == nil {
+		panic(fmt.Sprintf("checkvisttest: parent task %d not found", task.ParentID))
+	}
+	return s.taskOut(s.addTask(checklistID, task))
+}
+
+// AddNote adds a note to a task directly in the server's state.
+// AddNote panics if the task does not exist.
+func (s *Server) AddNote(taskID int, comment string) checkvist.Note {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task := s.tasks[taskID]
+	if task == nil {
+		panic(fmt.Sprintf("checkvisttest: task %d not found", taskID))
+	}
+	return *s.addNote(task, comment)
+}
+
+// Checklists returns all checklists, including archived ones, ordered by ID.
+func (s *Server) Checklists() []checkvist.Checklist {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []checkvist.Checklist
+	for _, cl := range s.sortedChecklists() {
+		result = append(result, s.checklistOut(cl))
+	}
+	return result
+}
+
+// Tasks returns the tasks of a checklist in tree order.
+func (s *Server) Tasks(checklistID int) []checkvist.Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listTasks(checklistID)
+}
+
+// Notes returns the notes of a task ordered by ID.
+func (s *Server) Notes(taskID int) []checkvist.Note {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listNotes(taskID)
+}
@@ -0,0 +1,230 @@
+package checkvisttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+func TestServer_Checklists(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	created, err := client.Checklists().Create(ctx, "Groceries")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Checklists().Update(ctx, created.ID, "Shopping"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	archived := server.AddChecklist("Old")
+	if _, err := client.Checklists().Archive(ctx, archived.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	active, err := client.Checklists().List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(active) != 1 || active[0].Name != "Shopping" {
+		t.Errorf("expected renamed active checklist, got %+v", active)
+	}
+	old, _ := client.Checklists().ListWithOptions(ctx, checkvist.ListOptions{Archived: true})
+	if len(old) != 1 || old[0].ID != archived.ID {
+		t.Errorf("expected archived checklist, got %+v", old)
+	}
+
+	if err := client.Checklists().Delete(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Checklists().Get(ctx, created.ID); !errors.Is(err, checkvist.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestServer_Tasks(t *testing.T) {
+	server := NewServer(WithClock(func() time.Time { return time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC) }))
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	cl := server.AddChecklist("Project")
+	tasks := client.Tasks(cl.ID)
+
+	parent, err := tasks.Create(ctx, checkvist.NewTask("Parent").WithTags("Work").WithDueDate(checkvist.DueTomorrow))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parent.DueDate == nil || parent.DueDate.Day() != 2 || !parent.Tags.Has("work") {
+		t.Errorf("expected due date and tags, got %+v", parent)
+	}
+	second, _ := tasks.Create(ctx, checkvist.NewTask("Second").WithParent(parent.ID))
+	first, _ := tasks.Create(ctx, checkvist.NewTask("First").WithParent(parent.ID).WithPosition(1))
+	sibling, _ := tasks.Create(ctx, checkvist.NewTask("Sibling"))
+
+	got, err := tasks.Get(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.ChildIDs) != 2 || got.ChildIDs[0] != first.ID || got.ChildIDs[1] != second.ID {
+		t.Errorf("expected children ordered by position, got %v", got.ChildIDs)
+	}
+
+	// Move the sibling under the parent, between the two children
+	parentID, position := parent.ID, 2
+	if _, err := tasks.Update(ctx, sibling.ID, checkvist.UpdateTaskRequest{ParentID: &parentID, Position: &position}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tree, err := tasks.Tree(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var order []string
+	for _, task := range tree.Tasks() {
+		order = append(order, task.Content)
+	}
+	if len(order) != 4 || order[1] != "First" || order[2] != "Sibling" || order[3] != "Second" {
+		t.Errorf("unexpected tree order: %v", order)
+	}
+
+	childID := first.ID
+	if _, err := tasks.Update(ctx, parent.ID, checkvist.UpdateTaskRequest{ParentID: &childID}); !errors.Is(err, checkvist.ErrBadRequest) {
+		t.Errorf("expected moving a task below itself to fail, got %v", err)
+	}
+
+	closed, err := tasks.Close(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if closed.Status != checkvist.StatusClosed {
+		t.Errorf("expected closed task, got %v", closed.Status)
+	}
+	for _, task := range server.Tasks(cl.ID) {
+		if task.Status != checkvist.StatusClosed {
+			t.Errorf("expected subtasks to be closed, got %q %v", task.Content, task.Status)
+		}
+	}
+	reopened, _ := tasks.Reopen(ctx, parent.ID)
+	invalidated, _ := tasks.Invalidate(ctx, second.ID)
+	if reopened.Status != checkvist.StatusOpen || invalidated.Status != checkvist.StatusInvalidated {
+		t.Errorf("unexpected statuses: %v, %v", reopened.Status, invalidated.Status)
+	}
+
+	if err := tasks.Delete(ctx, parent.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := server.Tasks(cl.ID); len(remaining) != 0 {
+		t.Errorf("expected subtree to be deleted, got %+v", remaining)
+	}
+}
+
+func TestServer_Notes(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	cl := server.AddChecklist("Notes")
+	task := server.AddTask(cl.ID, checkvist.Task{Content: "Task"})
+	server.AddNote(task.ID, "Seeded")
+
+	notes := client.Notes(cl.ID, task.ID)
+	created, err := notes.Create(ctx, "Created")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := notes.Update(ctx, created.ID, "Edited"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list, err := notes.List(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 || list[1].Comment != "Edited" {
+		t.Errorf("unexpected notes: %+v", list)
+	}
+	if got, _ := client.Tasks(cl.ID).Get(ctx, task.ID); got.CommentsCount != 2 {
+		t.Errorf("expected comments count 2, got %d", got.CommentsCount)
+	}
+
+	if err := notes.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(server.Notes(task.ID)) != 1 {
+		t.Errorf("expected one note after delete")
+	}
+}
+
+func TestServer_Auth(t *testing.T) {
+	server := NewServer(WithCredentials("me@example.com", "secret"), WithTOTP("123456"))
+	defer server.Close()
+	ctx := context.Background()
+
+	wrong := checkvist.NewClient("me@example.com", "wrong", checkvist.WithBaseURL(server.URL))
+	if err := wrong.Authenticate(ctx); !errors.Is(err, checkvist.ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+
+	client := server.Client(checkvist.WithTOTPProvider(func(context.Context) (string, error) {
+		return "123456", nil
+	}))
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Email != "me@example.com" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	// Expired tokens are rejected with 401, which makes the client log in again
+	server.ExpireTokens()
+	if _, err := client.Checklists().List(ctx); err != nil {
+		t.Fatalf("expected transparent re-authentication, got %v", err)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+	client.Authenticate(ctx)
+
+	server.InjectFault(Fault{Path: "/checklists.json", Times: 1, StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second})
+	server.InjectFault(Fault{Path: "/checklists.json", Times: 1, StatusCode: http.StatusServiceUnavailable})
+	server.InjectFault(Fault{Path: "/checklists.json", Times: 1, Drop: true})
+	server.InjectFault(Fault{Path: "/checklists.json", Times: 1, Latency: 20 * time.Millisecond})
+
+	start := time.Now()
+	if _, err := client.Checklists().List(ctx); err != nil {
+		t.Fatalf("expected retries to recover, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("expected injected latency, took %v", elapsed)
+	}
+
+	var attempts int
+	for _, req := range server.Requests() {
+		if req.Path == "/checklists.json" {
+			attempts++
+		}
+	}
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts, got %d", attempts)
+	}
+
+	server.InjectFault(Fault{Method: http.MethodGet, StatusCode: http.StatusInternalServerError})
+	if _, err := client.Checklists().List(ctx); !errors.Is(err, checkvist.ErrServerError) {
+		t.Errorf("expected ErrServerError once retries are exhausted, got %v", err)
+	}
+	server.ClearFaults()
+	if _, err := client.Checklists().List(ctx); err != nil {
+		t.Errorf("expected success after clearing faults, got %v", err)
+	}
+}
@@ -53,8 +53,24 @@ type Client struct {
 	retryConf RetryConfig
 	// logger is the logger for debug and error messages.
 	logger *slog.Logger
+	// tokenStore persists tokens across clients, if configured.
+	tokenStore TokenStore
+	// totpProvider supplies 2FA codes for automatic logins, if configured.
+	totpProvider TOTPProvider
+	// limiter spaces out API requests, if configured.
+	limiter *RateLimiter
+	// middleware wraps every HTTP request, outermost first.
+	middleware []Middleware
+	// observer receives request callbacks for tracing and metrics, if configured.
+	observer Observer
+	// cache stores GET responses for conditional requests, if configured.
+	cache Cache
 	// mu protects token and tokenExp for concurrent access.
 	mu sync.RWMutex
+	// authMu protects authFlight.
+	authMu sync.Mutex
+	// authFlight is the authentication or refresh currently in progress, if any.
+	authFlight *authFlight
 }
 
 // NewClient creates a new Checkvist API client.
@@ -125,7 +141,7 @@ func (c *Client) authenticate(ctx context.Context, twoFAToken string) error {
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.observe(req, "/auth/login.json", 0)
 	if err != nil {
 		return fmt.Errorf("auth request failed: %w", err)
 	}
@@ -133,6 +149,11 @@ func (c *Client) authenticate(ctx context.Context, twoFAToken string) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
+		// Only rejected credentials invalidate the stored token; rate limits
+		// and server errors are temporary.
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			c.clearStoredToken(ctx)
+		}
 		return NewAPIError(resp, string(body))
 	}
 
@@ -141,11 +162,8 @@ func (c *Client) authenticate(ctx context.Context, twoFAToken string) error {
 		return fmt.Errorf("decoding auth response: %w", err)
 	}
 
-	c.mu.Lock()
-	c.token = authResp.Token
 	// Token is valid for 1 day, but we refresh earlier to be safe
-	c.tokenExp = time.Now().Add(23 * time.Hour)
-	c.mu.Unlock()
+	c.setToken(ctx, authResp.Token, time.Now().Add(23*time.Hour))
 
 	c.logger.Debug("authenticated successfully", "username", c.username)
 	return nil
@@ -158,7 +176,7 @@ func (c *Client) refreshToken(ctx context.Context) error {
 	c.mu.RUnlock()
 
 	if currentToken == "" {
-		return c.Authenticate(ctx)
+		return c.login(ctx)
 	}
 
 	data := url.Values{}
@@ -172,7 +190,7 @@ func (c *Client) refreshToken(ctx context.Context) error {
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.observe(req, "/auth/refresh_token.json", 0)
 	if err != nil {
 		return fmt.Errorf("refresh request failed: %w", err)
 	}
@@ -181,7 +199,7 @@ func (c *Client) refreshToken(ctx context.Context) error {
 	if resp.StatusCode != http.StatusOK {
 		// If refresh fails, try full authentication
 		c.logger.Debug("token refresh failed, attempting full authentication")
-		return c.Authenticate(ctx)
+		return c.login(ctx)
 	}
 
 	var authResp authResponse
@@ -189,11 +207,8 @@ func (c *Client) refreshToken(ctx context.Context) error {
 		return fmt.Errorf("decoding refresh response: %w", err)
 	}
 
-	c.mu.Lock()
-	c.token = authResp.Token
 	// Refreshed tokens can be valid for up to 90 days, but we refresh more frequently
-	c.tokenExp = time.Now().Add(23 * time.Hour)
-	c.mu.Unlock()
+	c.setToken(ctx, authResp.Token, time.Now().Add(23*time.Hour))
 
 	c.logger.Debug("token refreshed successfully")
 	return nil
@@ -201,22 +216,107 @@ func (c *Client) refreshToken(ctx context.Context) error {
 
 // ensureAuthenticated ensures the client has a valid authentication token.
 // This is called automatically before each API request.
+//
+// Concurrent callers that find the token missing or about to expire share a
+// single in-flight authentication or refresh; see singleFlightAuth.
 func (c *Client) ensureAuthenticated(ctx context.Context) error {
+	if !c.needsRenewal() {
+		return nil
+	}
+	return c.singleFlightAuth(ctx, c.renewToken)
+}
+
+// needsRenewal reports whether the token is missing or will expire within
+// the next hour.
+func (c *Client) needsRenewal() bool {
 	c.mu.RLock()
-	token := c.token
-	tokenExp := c.tokenExp
-	c.mu.RUnlock()
+	defer c.mu.RUnlock()
+	return c.token == "" || time.Now().Add(1*time.Hour).After(c.tokenExp)
+}
 
-	if token == "" {
-		return c.Authenticate(ctx)
+// renewToken obtains a valid token, from the token store, by refreshing the
+// current token or by logging in, in that order of preference.
+func (c *Client) renewToken(ctx context.Context) error {
+	// Another caller may have renewed the token in the meantime.
+	if !c.needsRenewal() {
+		return nil
 	}
+	if c.getToken() == "" && c.loadStoredToken(ctx) && !c.needsRenewal() {
+		return nil
+	}
+	if c.getToken() == "" {
+		return c.login(ctx)
+	}
+	return c.refreshToken(ctx)
+}
 
-	// Refresh token if it will expire within the next hour
-	if time.Now().Add(1 * time.Hour).After(tokenExp) {
-		return c.refreshToken(ctx)
+// login performs a full authentication on behalf of the client, asking the
+// configured TOTP provider for a 2FA code if there is one.
+func (c *Client) login(ctx context.Context) error {
+	code := ""
+	if c.totpProvider != nil {
+		var err error
+		if code, err = c.totpProvider(ctx); err != nil {
+			return fmt.Errorf("obtaining 2FA code: %w", err)
+		}
 	}
+	return c.authenticate(ctx, code)
+}
 
-	return nil
+// invalidateToken discards the token if it is still the current one, both in
+// memory and in the token store. Tokens that have already been replaced by
+// another caller are left alone.
+func (c *Client) invalidateToken(ctx context.Context, token string) {
+	c.mu.Lock()
+	current := c.token == token
+	if current {
+		c.token = ""
+		c.tokenExp = time.Time{}
+	}
+	c.mu.Unlock()
+
+	if !current || c.tokenStore == nil {
+		return
+	}
+	if stored, err := c.tokenStore.Load(ctx, c.username); err == nil && stored != nil && stored.Value == token {
+		c.clearStoredToken(ctx)
+	}
+}
+
+// authFlight is an authentication or refresh shared by concurrent callers.
+type authFlight struct {
+	done chan struct{}
+	err  error
+}
+
+// singleFlightAuth runs fn unless a previous call is still in flight, in
+// which case it waits for that call's result instead. fn runs detached from
+// the cancellation of any single caller, so a caller that gives up does not
+// fail the others; each caller still returns as soon as its own context is done.
+func (c *Client) singleFlightAuth(ctx context.Context, fn func(context.Context) error) error {
+	c.authMu.Lock()
+	flight := c.authFlight
+	if flight == nil {
+		flight = &authFlight{done: make(chan struct{})}
+		c.authFlight = flight
+		go func() {
+			flight.err = fn(context.WithoutCancel(ctx))
+			c.authMu.Lock()
+			c.authFlight = nil
+			c.authMu.Unlock()
+			close(flight.done)
+		}()
+	} else {
+		c.logger.Debug("waiting for in-flight authentication")
+	}
+	c.authMu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-flight.done:
+		return flight.err
+	}
 }
 
 // getToken returns the current authentication token.
@@ -240,7 +340,7 @@ func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
 	}
 	req.Header.Set("X-Client-Token", c.getToken())
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.observe(req, "/auth/curr_user.json", 0)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -266,19 +366,36 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, r
 		return err
 	}
 
-	var bodyReader io.Reader
+	var bodyBytes []byte
 	if body != nil {
-		bodyBytes, err := json.Marshal(body)
-		if err != nil {
+		var err error
+		if bodyBytes, err = json.Marshal(body); err != nil {
 			return fmt.Errorf("marshaling request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	var cached *CacheEntry
+	if c.cache != nil {
+		if method == http.MethodGet {
+			if entry, ok := c.cache.Get(c.cacheKey(path)); ok {
+				cached = &entry
+			}
+		} else {
+			defer c.invalidateCache(path)
+		}
 	}
 
 	var lastErr error
+	var serverDelay time.Duration
+	reauthenticated := false
+	replay := false
 	for attempt := 0; attempt <= c.retryConf.MaxRetries; attempt++ {
-		if attempt > 0 {
+		if attempt > 0 && !replay {
 			delay := c.calculateRetryDelay(attempt)
+			if serverDelay > 0 {
+				// Honor the server's Retry-After or rate limit reset hint
+				delay = min(serverDelay, c.retryConf.MaxDelay)
+			}
 			c.logger.Debug("retrying request",
 				"attempt", attempt,
 				"delay", delay,
@@ -290,12 +407,16 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, r
 				return ctx.Err()
 			case <-time.After(delay):
 			}
+		}
+		replay = false
 
-			// Reset body reader for retry
-			if body != nil {
-				bodyBytes, _ := json.Marshal(body)
-				bodyReader = bytes.NewReader(bodyBytes)
-			}
+		if err := c.waitForRateLimit(ctx, path); err != nil {
+			return err
+		}
+
+		var bodyReader io.Reader
+		if bodyBytes != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
 		}
 
 		req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
@@ -303,10 +424,14 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, r
 			return fmt.Errorf("creating request: %w", err)
 		}
 
-		req.Header.Set("X-Client-Token", c.getToken())
+		token := c.getToken()
+		req.Header.Set("X-Client-Token", token)
 		if body != nil {
 			req.Header.Set("Content-Type", "application/json")
 		}
+		if cached != nil {
+			setConditionalHeaders(req, *cached)
+		}
 
 		c.logger.Debug("sending request",
 			"method", method,
@@ -314,7 +439,8 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, r
 			"attempt", attempt,
 		)
 
-		resp, err := c.httpClient.Do(req)
+		serverDelay = 0
+		resp, err := c.observe(req, path, attempt)
 		if err != nil {
 			if c.shouldRetry(err, nil) {
 				lastErr = err
@@ -336,6 +462,14 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, r
 			"path", path,
 		)
 
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			c.logger.Debug("serving cached response", "path", path)
+			respBody = cached.Body
+			resp.StatusCode = http.StatusOK
+		} else if c.cache != nil && method == http.MethodGet && resp.StatusCode == http.StatusOK {
+			c.storeResponse(path, resp, respBody)
+		}
+
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 			if result != nil && len(respBody) > 0 {
 				if err := json.Unmarshal(respBody, result); err != nil {
@@ -345,9 +479,24 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, r
 			return nil
 		}
 
+		// A revoked or expired token: log in again once and replay the
+		// request without counting it as a retry.
+		if resp.StatusCode == http.StatusUnauthorized && !reauthenticated {
+			reauthenticated = true
+			c.logger.Debug("token rejected, re-authenticating", "path", path)
+			c.invalidateToken(ctx, token)
+			if err := c.ensureAuthenticated(ctx); err != nil {
+				return err
+			}
+			replay = true
+			attempt--
+			continue
+		}
+
 		apiErr := NewAPIError(resp, string(respBody))
 		if c.shouldRetry(nil, resp) {
 			lastErr = apiErr
+			serverDelay = apiErr.retryDelay(time.Now())
 			continue
 		}
 
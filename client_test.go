@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -473,6 +475,110 @@ func TestCalculateRetryDelay(t *testing.T) {
 	}
 }
 
+func TestNewAPIError_RateLimitHeaders(t *testing.T) {
+	now := time.Now()
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Retry-After":           {"7"},
+			"X-Ratelimit-Limit":     {"100"},
+			"X-Ratelimit-Remaining": {"0"},
+			"X-Ratelimit-Reset":     {"30"},
+		},
+	}
+
+	apiErr := NewAPIError(resp, "")
+	if apiErr.RetryAfter != 7*time.Second {
+		t.Errorf("expected RetryAfter 7s, got %v", apiErr.RetryAfter)
+	}
+	if apiErr.Limit != 100 || apiErr.Remaining != 0 {
+		t.Errorf("expected limit 100 and remaining 0, got %d and %d", apiErr.Limit, apiErr.Remaining)
+	}
+	if d := apiErr.Reset.Sub(now); d < 29*time.Second || d > 31*time.Second {
+		t.Errorf("expected reset in 30s, got %v", d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{"-1", 0},
+		{"Thu, 01 Jan 2026 12:00:30 GMT", 30 * time.Second},
+		{"Thu, 01 Jan 2026 11:59:00 GMT", 0},
+		{"soon", 0},
+	}
+
+	for _, tc := range tests {
+		if got := parseRetryAfter(tc.value, now); got != tc.expected {
+			t.Errorf("%q: expected %v, got %v", tc.value, tc.expected, got)
+		}
+	}
+}
+
+func TestParseRateLimitReset(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if got := parseRateLimitReset("60", now); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected relative reset, got %v", got)
+	}
+	epoch := now.Add(time.Hour).Unix()
+	if got := parseRateLimitReset(strconv.FormatInt(epoch, 10), now); got.Unix() != epoch {
+		t.Errorf("expected epoch reset, got %v", got)
+	}
+	if got := parseRateLimitReset("", now); !got.IsZero() {
+		t.Errorf("expected zero time, got %v", got)
+	}
+}
+
+func TestRetryLogic_RetryAfter(t *testing.T) {
+	var attempts int32
+	var first, second time.Time
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/test":
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				first = time.Now()
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			second = time.Now()
+			w.Write([]byte(`{"success": true}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key",
+		WithBaseURL(server.URL),
+		WithRetryConfig(RetryConfig{
+			MaxRetries: 3,
+			BaseDelay:  1 * time.Millisecond,
+			MaxDelay:   50 * time.Millisecond,
+			Jitter:     false,
+		}),
+	)
+
+	var result map[string]bool
+	if err := client.doGet(context.Background(), "/test", &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Retry-After asks for 1s, which is capped at MaxDelay
+	if d := second.Sub(first); d < 40*time.Millisecond || d > 500*time.Millisecond {
+		t.Errorf("expected retry after about 50ms, got %v", d)
+	}
+}
+
 func TestDefaultRetryConfig(t *testing.T) {
 	config := DefaultRetryConfig()
 
@@ -489,3 +595,269 @@ func TestDefaultRetryConfig(t *testing.T) {
 		t.Error("expected Jitter to be true")
 	}
 }
+
+func TestEnsureAuthenticated_ConcurrentLogin(t *testing.T) {
+	var loginCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			atomic.AddInt32(&loginCalls, 1)
+			time.Sleep(50 * time.Millisecond)
+			json.NewEncoder(w).Encode(map[string]string{"token": "shared-token"})
+		case "/checklists.json":
+			if r.Header.Get("X-Client-Token") != "shared-token" {
+				t.Errorf("expected shared-token, got %s", r.Header.Get("X-Client-Token"))
+			}
+			w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+
+	const goroutines = 50
+	errs := make(chan error, goroutines)
+	start := make(chan struct{})
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			<-start
+			_, err := client.Checklists().List(context.Background())
+			errs <- err
+		}()
+	}
+	close(start)
+
+	for i := 0; i < goroutines; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&loginCalls); n != 1 {
+		t.Errorf("expected 1 login request, got %d", n)
+	}
+}
+
+func TestEnsureAuthenticated_ConcurrentRefresh(t *testing.T) {
+	var loginCalls, refreshCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			atomic.AddInt32(&loginCalls, 1)
+			json.NewEncoder(w).Encode(map[string]string{"token": "initial-token"})
+		case "/auth/refresh_token.json":
+			atomic.AddInt32(&refreshCalls, 1)
+			time.Sleep(50 * time.Millisecond)
+			json.NewEncoder(w).Encode(map[string]string{"token": "refreshed-token"})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	client.mu.Lock()
+	client.token = "initial-token"
+	client.tokenExp = time.Now().Add(30 * time.Minute)
+	client.mu.Unlock()
+
+	const goroutines = 50
+	errs := make(chan error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		go func() {
+			errs <- client.ensureAuthenticated(context.Background())
+		}()
+	}
+	for i := 0; i < goroutines; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if n := atomic.LoadInt32(&refreshCalls); n != 1 {
+		t.Errorf("expected 1 refresh request, got %d", n)
+	}
+	if n := atomic.LoadInt32(&loginCalls); n != 0 {
+		t.Errorf("expected no login request, got %d", n)
+	}
+	if client.getToken() != "refreshed-token" {
+		t.Errorf("expected refreshed-token, got %s", client.getToken())
+	}
+}
+
+func TestEnsureAuthenticated_WaiterCancellation(t *testing.T) {
+	release := make(chan struct{})
+	var loginCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&loginCalls, 1)
+		<-release
+		json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+
+	// The first caller starts the login and cancels while it is in flight.
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		leader <- client.ensureAuthenticated(ctx)
+	}()
+
+	for atomic.LoadInt32(&loginCalls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiter := make(chan error, 1)
+	go func() {
+		waiter <- client.ensureAuthenticated(context.Background())
+	}()
+
+	cancel()
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled for cancelled caller, got %v", err)
+	}
+
+	close(release)
+	if err := <-waiter; err != nil {
+		t.Errorf("expected waiter to succeed, got %v", err)
+	}
+	if n := atomic.LoadInt32(&loginCalls); n != 1 {
+		t.Errorf("expected 1 login request, got %d", n)
+	}
+	if client.getToken() != "test-token" {
+		t.Errorf("expected test-token, got %s", client.getToken())
+	}
+}
+
+func TestDoRequest_ReauthenticatesOn401(t *testing.T) {
+	var loginCalls, apiCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			atomic.AddInt32(&loginCalls, 1)
+			json.NewEncoder(w).Encode(map[string]string{"token": "new-token"})
+		case "/checklists.json":
+			atomic.AddInt32(&apiCalls, 1)
+			if r.Header.Get("X-Client-Token") != "new-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	store := NewMemoryTokenStore()
+	client := NewClient("user@example.com", "api-key",
+		WithBaseURL(server.URL),
+		WithRetryConfig(RetryConfig{MaxRetries: 0}),
+		WithTokenStore(store),
+	)
+	client.setToken(context.Background(), "revoked-token", time.Now().Add(12*time.Hour))
+
+	if _, err := client.Checklists().List(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loginCalls != 1 || apiCalls != 2 {
+		t.Errorf("expected 1 login and 2 API calls, got %d and %d", loginCalls, apiCalls)
+	}
+	if token, _ := store.Load(context.Background(), "user@example.com"); token == nil || token.Value != "new-token" {
+		t.Errorf("expected stored token to be replaced, got %+v", token)
+	}
+}
+
+func TestDoRequest_ReauthenticatesOnlyOnce(t *testing.T) {
+	var loginCalls, apiCalls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			atomic.AddInt32(&loginCalls, 1)
+			json.NewEncoder(w).Encode(map[string]string{"token": "token"})
+		case "/checklists.json":
+			atomic.AddInt32(&apiCalls, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	_, err := client.Checklists().List(context.Background())
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+	if loginCalls != 2 || apiCalls != 2 {
+		t.Errorf("expected 2 logins and 2 API calls, got %d and %d", loginCalls, apiCalls)
+	}
+}
+
+func TestDoRequest_ReauthenticateWithTOTPProvider(t *testing.T) {
+	var codes []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			r.ParseForm()
+			codes = append(codes, r.Form.Get("totp"))
+			json.NewEncoder(w).Encode(map[string]string{"token": "new-token"})
+		case "/checklists.json":
+			if r.Header.Get("X-Client-Token") != "new-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key",
+		WithBaseURL(server.URL),
+		WithTOTPProvider(func(ctx context.Context) (string, error) {
+			return "654321", nil
+		}),
+	)
+	client.setToken(context.Background(), "revoked-token", time.Now().Add(12*time.Hour))
+
+	if _, err := client.Checklists().List(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != 1 || codes[0] != "654321" {
+		t.Errorf("expected login with 2FA code 654321, got %v", codes)
+	}
+
+	t.Run("provider error", func(t *testing.T) {
+		client := NewClient("user@example.com", "api-key",
+			WithBaseURL(server.URL),
+			WithTOTPProvider(func(ctx context.Context) (string, error) {
+				return "", errors.New("no code")
+			}),
+		)
+		_, err := client.Checklists().List(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "obtaining 2FA code") {
+			t.Errorf("expected 2FA provider error, got %v", err)
+		}
+	})
+}
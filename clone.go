@@ -0,0 +1,189 @@
+package checkvist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// clone.go contains deep-copying of checklists for use as templates.
+
+// CloneOptions configures the Clone operation.
+type CloneOptions struct {
+	// Name is the name of the new checklist. Defaults to the source checklist's name.
+	// Placeholders are substituted like in task content.
+	Name string
+	// Data enables {{placeholder}} substitution in the checklist name, task
+	// content and notes using text/template when non-nil. Typically a
+	// map[string]any or a struct. Referencing a missing map key is an error.
+	Data any
+	// BaseDate shifts due dates when non-zero: every due date is moved by the
+	// number of days between SourceBaseDate and BaseDate.
+	BaseDate time.Time
+	// SourceBaseDate is the date the source due dates are relative to.
+	// Defaults to the earliest due date in the source checklist.
+	SourceBaseDate time.Time
+	// ResetStatus creates all tasks as open instead of copying their status.
+	ResetStatus bool
+	// Progress is called after each task has been copied with the number of
+	// tasks copied so far and the total number of tasks.
+	Progress func(done, total int)
+}
+
+// Clone creates a new checklist and recreates the full task tree of the
+// source checklist in it, including notes, tags, priorities and due dates.
+//
+// Placeholders in the name, task content and notes are expanded before the
+// new checklist is created, so a template error creates nothing. If copying
+// fails part-way, the partially filled checklist is returned together with
+// the error so the caller can inspect or delete it.
+func (s *ChecklistService) Clone(ctx context.Context, sourceID int, opts CloneOptions) (*Checklist, error) {
+	source, err := s.Get(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	srcTasks := s.client.Tasks(sourceID)
+	tree, err := srcTasks.Tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = source.Name
+	}
+	if name, err = expandTemplate("checklist name", name, opts.Data); err != nil {
+		return nil, err
+	}
+
+	shift := 0
+	if !opts.BaseDate.IsZero() {
+		base := opts.SourceBaseDate
+		if base.IsZero() {
+			base = earliestDueDate(tree.Tasks())
+		}
+		if !base.IsZero() {
+			shift = daysBetween(base, opts.BaseDate)
+		}
+	}
+
+	expanded, err := expandTasks(ctx, srcTasks, tree.Tasks(), opts.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	clone, err := s.Create(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(tree.Tasks())
+	done := 0
+	copyOpts := copyOptions{
+		keepOpen:  opts.ResetStatus,
+		transform: cloneTransform(expanded, shift),
+		created: func(Task, *Task) {
+			done++
+			if opts.Progress != nil {
+				opts.Progress(done, total)
+			}
+		},
+	}
+
+	dst := s.client.Tasks(clone.ID)
+	for _, root := range tree.Roots() {
+		if _, err := dst.copySubtree(ctx, root, 0, 0, copyOpts); err != nil {
+			return clone, fmt.Errorf("cloning checklist %d: %w", sourceID, err)
+		}
+	}
+
+	return clone, nil
+}
+
+// expandedTask holds the content and note texts of a source task with
+// placeholders substituted.
+type expandedTask struct {
+	content string
+	notes   []string
+}
+
+// expandTasks fetches the notes of the tasks and substitutes placeholders in
+// their content and notes, keyed by source task ID.
+func expandTasks(ctx context.Context, src *TaskService, tasks []Task, data any) (map[int]expandedTask, error) {
+	expanded := make(map[int]expandedTask, len(tasks))
+	for _, task := range tasks {
+		content, err := expandTemplate(fmt.Sprintf("task %d", task.ID), task.Content, data)
+		if err != nil {
+			return nil, err
+		}
+
+		notes, err := src.sourceNotes(ctx, task)
+		if err != nil {
+			return nil, fmt.Errorf("listing notes of task %d: %w", task.ID, err)
+		}
+		comments := make([]string, len(notes))
+		for i, note := range notes {
+			if comments[i], err = expandTemplate(fmt.Sprintf("note of task %d", task.ID), note.Comment, data); err != nil {
+				return nil, err
+			}
+		}
+
+		expanded[task.ID] = expandedTask{content: content, notes: comments}
+	}
+	return expanded, nil
+}
+
+// cloneTransform returns the copy transform that applies the expanded
+// content and notes and shifts due dates by the given number of days.
+func cloneTransform(expanded map[int]expandedTask, shift int) func(Task, *TaskBuilder, []string) ([]string, error) {
+	return func(task Task, builder *TaskBuilder, _ []string) ([]string, error) {
+		builder.content = expanded[task.ID].content
+		if shift != 0 && task.DueDate != nil {
+			builder.WithDueDate(DueAt(task.DueDate.AddDate(0, 0, shift)))
+		}
+		return expanded[task.ID].notes, nil
+	}
+}
+
+// expandTemplate executes text as a text/template with data. Text without
+// placeholders, or a nil data value, is returned unchanged.
+func expandTemplate(name, text string, data any) (string, error) {
+	if data == nil || !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing template in %s: %w", name, err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("expanding template in %s: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+// earliestDueDate returns the earliest parsed due date among the tasks,
+// or the zero time if none has one.
+func earliestDueDate(tasks []Task) time.Time {
+	var earliest time.Time
+	for _, task := range tasks {
+		if task.DueDate != nil && (earliest.IsZero() || task.DueDate.Before(earliest)) {
+			earliest = *task.DueDate
+		}
+	}
+	return earliest
+}
+
+// daysBetween returns the number of calendar days from a to b.
+func daysBetween(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	from := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	to := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
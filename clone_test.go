@@ -0,0 +1,186 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChecklists_Clone(t *testing.T) {
+	var (
+		createdName string
+		created     []CreateTaskRequest
+		notes       []string
+		closed      int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/1.json":
+			json.NewEncoder(w).Encode(Checklist{ID: 1, Name: "Onboarding template"})
+		case "/checklists/1/tasks.json":
+			json.NewEncoder(w).Encode([]Task{
+				{ID: 101, Content: "Welcome {{.Name}}", DueDateRaw: "2026/01/10", Status: StatusClosed, TagsAsText: "hr"},
+				{ID: 102, ParentID: 101, Content: "Set up laptop", DueDateRaw: "2026/01/12", CommentsCount: 1, Priority: 2},
+			})
+		case "/checklists/1/tasks/102/comments.json":
+			json.NewEncoder(w).Encode([]Note{{ID: 1, TaskID: 102, Comment: "Ask {{.Buddy}}"}})
+		case "/checklists.json":
+			var req createChecklistRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			createdName = req.Name
+			json.NewEncoder(w).Encode(Checklist{ID: 2, Name: req.Name})
+		case "/checklists/2/tasks.json":
+			var req createTaskWrapper
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			created = append(created, req.Task)
+			json.NewEncoder(w).Encode(Task{ID: 200 + len(created), ChecklistID: 2, Content: req.Task.Content})
+		case "/checklists/2/tasks/202/comments.json":
+			var req createNoteRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			notes = append(notes, req.Comment.Comment)
+			json.NewEncoder(w).Encode(Note{ID: 1, TaskID: 202, Comment: req.Comment.Comment})
+		case "/checklists/2/tasks/201/close.json":
+			closed++
+			json.NewEncoder(w).Encode([]Task{{ID: 201, Status: StatusClosed}})
+		default:
+			t.Errorf("unexpected path: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var progress []int
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	clone, err := client.Checklists().Clone(context.Background(), 1, CloneOptions{
+		Name:     "Onboarding {{.Name}}",
+		Data:     map[string]any{"Name": "Alice", "Buddy": "Bob"},
+		BaseDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Progress: func(done, total int) {
+			if total != 2 {
+				t.Errorf("expected total 2, got %d", total)
+			}
+			progress = append(progress, done)
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clone.ID != 2 || createdName != "Onboarding Alice" {
+		t.Errorf("expected new checklist 'Onboarding Alice', got %d %q", clone.ID, createdName)
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected 2 created tasks, got %d", len(created))
+	}
+	if created[0].Content != "Welcome Alice" || created[0].Due != "2026-03-01" || created[0].Tags != "hr" {
+		t.Errorf("unexpected root copy: %+v", created[0])
+	}
+	if created[1].ParentID != 201 || created[1].Due != "2026-03-03" || created[1].Priority != 2 {
+		t.Errorf("unexpected child copy: %+v", created[1])
+	}
+	if len(notes) != 1 || notes[0] != "Ask Bob" {
+		t.Errorf("expected substituted note, got %v", notes)
+	}
+	if closed != 1 {
+		t.Errorf("expected closed status to be copied, got %d close calls", closed)
+	}
+	if len(progress) != 2 || progress[1] != 2 {
+		t.Errorf("expected progress [1 2], got %v", progress)
+	}
+}
+
+func TestChecklists_Clone_TemplateErrorCreatesNothing(t *testing.T) {
+	tests := map[string]string{
+		"content": "Ask {{.Buddy}}",
+		"note":    "Welcome",
+	}
+
+	for name, childContent := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.URL.Path {
+				case "/auth/login.json":
+					json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+				case "/checklists/1.json":
+					json.NewEncoder(w).Encode(Checklist{ID: 1, Name: "Onboarding template"})
+				case "/checklists/1/tasks.json":
+					json.NewEncoder(w).Encode([]Task{
+						{ID: 101, Content: "Welcome {{.Name}}"},
+						{ID: 102, ParentID: 101, Content: childContent, CommentsCount: 1},
+					})
+				case "/checklists/1/tasks/102/comments.json":
+					comment := "Ask {{.Buddy}}"
+					if name == "content" {
+						comment = "Done"
+					}
+					json.NewEncoder(w).Encode([]Note{{ID: 1, TaskID: 102, Comment: comment}})
+				default:
+					t.Errorf("unexpected request after template error: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+			clone, err := client.Checklists().Clone(context.Background(), 1, CloneOptions{
+				Data: map[string]any{"Name": "Alice"},
+			})
+			if err == nil || !strings.Contains(err.Error(), "task 102") {
+				t.Errorf("expected template error for task 102, got %v", err)
+			}
+			if clone != nil {
+				t.Errorf("expected no checklist, got %+v", clone)
+			}
+		})
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		data     any
+		expected string
+		wantErr  string
+	}{
+		{"no data", "Hello {{.Name}}", nil, "Hello {{.Name}}", ""},
+		{"no placeholders", "Hello", map[string]any{"Name": "Alice"}, "Hello", ""},
+		{"substitution", "Hello {{.Name}}", map[string]any{"Name": "Alice"}, "Hello Alice", ""},
+		{"missing key", "Hello {{.Name}}", map[string]any{}, "", "expanding template"},
+		{"syntax error", "Hello {{.Name", map[string]any{}, "", "parsing template"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := expandTemplate("test", tc.text, tc.data)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+// commands.go contains the implementations of the subcommands.
+
+func cmdLogin(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("login")
+	totp := fs.String("totp", "", "two-factor authentication code")
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+	if *totp != "" {
+		err = client.AuthenticateWith2FA(ctx, *totp)
+	} else {
+		err = client.Authenticate(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	return a.print(user, []string{"ID", "USERNAME", "EMAIL"},
+		[][]string{{strconv.Itoa(user.ID), user.Username, user.Email}})
+}
+
+func cmdLists(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("lists")
+	archived := fs.Bool("archived", false, "list archived checklists")
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+	checklists, err := client.Checklists().ListWithOptions(ctx, checkvist.ListOptions{Archived: *archived})
+	if err != nil {
+		return err
+	}
+	return a.printChecklists(checklists)
+}
+
+func cmdShow(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("show")
+	tags := fs.String("tag", "", "only tasks with all of these comma-separated tags")
+	status := fs.String("status", "", "only tasks with this status: open, closed or invalidated")
+	search := fs.String("search", "", "only tasks containing this text")
+	overdue := fs.Bool("overdue", false, "only open tasks that are overdue")
+	query := fs.String("q", "", "search query, e.g. '#urgent status:open due:<2026-11-01'")
+	positional, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	filter := checkvist.NewFilter(nil)
+	if *query != "" {
+		if filter, err = checkvist.ParseQuery(*query); err != nil {
+			return fmt.Errorf("%w: %v", errUsage, err)
+		}
+	}
+
+	client, checklist, err := a.checklist(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	tasks, err := client.Tasks(checklist.ID).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *tags != "" {
+		filter.WithTags(splitList(*tags)...)
+	}
+	if *status != "" {
+		s, err := parseStatus(*status)
+		if err != nil {
+			return err
+		}
+		filter.WithStatus(s)
+	}
+	if *search != "" {
+		filter.WithSearch(*search)
+	}
+	if *overdue {
+		filter.WithOverdue()
+	}
+
+	return a.printTasks(filter.ApplyTo(tasks), checkvist.NewTaskTree(tasks))
+}
+
+func cmdAdd(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("add")
+	parent := fs.Int("parent", 0, "parent task ID")
+	position := fs.Int("position", 0, "position among the siblings, starting at 1")
+	due := fs.String("due", "", "due date, e.g. 2026-11-01, today or tomorrow")
+	priority := fs.Int("priority", 0, "priority: 1 (highest), 2 (high) or 0 (normal)")
+	tags := fs.String("tags", "", "comma-separated tags")
+	positional, err := parse(fs, args, 2, -1)
+	if err != nil {
+		return err
+	}
+
+	client, checklist, err := a.checklist(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	builder := checkvist.NewTask(strings.Join(positional[1:], " ")).
+		WithParent(*parent).
+		WithPosition(*position).
+		WithPriority(*priority)
+	if *due != "" {
+		builder.WithDueDate(checkvist.DueString(*due))
+	}
+	if *tags != "" {
+		builder.WithTags(splitList(*tags)...)
+	}
+
+	task, err := client.Tasks(checklist.ID).Create(ctx, builder)
+	if err != nil {
+		return err
+	}
+	return a.printTasks([]checkvist.Task{*task}, nil)
+}
+
+// statusCommand returns a command that applies a status change to tasks.
+func statusCommand(change func(*checkvist.TaskService, context.Context, int) (*checkvist.Task, error)) func(context.Context, *app, []string) error {
+	return func(ctx context.Context, a *app, args []string) error {
+		client, checklist, ids, err := a.taskArgs(ctx, "status", args)
+		if err != nil {
+			return err
+		}
+
+		service := client.Tasks(checklist.ID)
+		var changed []checkvist.Task
+		for _, id := range ids {
+			task, err := change(service, ctx, id)
+			if err != nil {
+				return fmt.Errorf("task %d: %w", id, err)
+			}
+			changed = append(changed, *task)
+		}
+		return a.printTasks(changed, nil)
+	}
+}
+
+func cmdRemove(ctx context.Context, a *app, args []string) error {
+	client, checklist, ids, err := a.taskArgs(ctx, "rm", args)
+	if err != nil {
+		return err
+	}
+
+	service := client.Tasks(checklist.ID)
+	for _, id := range ids {
+		if err := service.Delete(ctx, id); err != nil {
+			return fmt.Errorf("task %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func cmdNote(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("note")
+	positional, err := parse(fs, args, 2, -1)
+	if err != nil {
+		return err
+	}
+	client, checklist, err := a.checklist(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	taskID, err := parseID(positional[1])
+	if err != nil {
+		return err
+	}
+
+	notes := client.Notes(checklist.ID, taskID)
+	var result []checkvist.Note
+	if len(positional) > 2 {
+		note, err := notes.Create(ctx, strings.Join(positional[2:], " "))
+		if err != nil {
+			return err
+		}
+		result = []checkvist.Note{*note}
+	} else if result, err = notes.List(ctx); err != nil {
+		return err
+	}
+	return a.printNotes(result)
+}
+
+func cmdTag(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("tag")
+	remove := fs.String("remove", "", "comma-separated tags to remove")
+	positional, err := parse(fs, args, 2, -1)
+	if err != nil {
+		return err
+	}
+	client, checklist, err := a.checklist(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	taskID, err := parseID(positional[1])
+	if err != nil {
+		return err
+	}
+
+	task, err := client.Tasks(checklist.ID).UpdateTags(ctx, taskID, checkvist.TagUpdate{
+		Add:    positional[2:],
+		Remove: splitList(*remove),
+	})
+	if err != nil {
+		return err
+	}
+	return a.printTasks([]checkvist.Task{*task}, nil)
+}
+
+func cmdDue(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("due")
+	positional, err := parse(fs, args, 3, 3)
+	if err != nil {
+		return err
+	}
+	client, checklist, err := a.checklist(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	taskID, err := parseID(positional[1])
+	if err != nil {
+		return err
+	}
+
+	due := positional[2]
+	if strings.EqualFold(due, "none") {
+		due = ""
+	}
+	task, err := client.Tasks(checklist.ID).Update(ctx, taskID, checkvist.UpdateTaskRequest{Due: &due})
+	if err != nil {
+		return err
+	}
+	return a.printTasks([]checkvist.Task{*task}, nil)
+}
+
+func cmdExport(ctx context.Context, a *app, args []string) error {
+	fs := a.flags("export")
+	as := fs.String("as", "markdown", "export format: markdown, opml or json")
+	withNotes := fs.Bool("notes", false, "include notes (one request per task with notes)")
+	output := fs.String("o", "", "write to file instead of standard output")
+	positional, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(*as) {
+	case "markdown", "md", "opml", "json":
+	default:
+		return fmt.Errorf("%w: unknown export format %q", errUsage, *as)
+	}
+
+	client, checklist, err := a.checklist(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+	tasks, err := client.Tasks(checklist.ID).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	notes := make(map[int][]checkvist.Note)
+	if *withNotes {
+		for i, task := range tasks {
+			if task.CommentsCount == 0 {
+				continue
+			}
+			list, err := client.Notes(checklist.ID, task.ID).List(ctx)
+			if err != nil {
+				return fmt.Errorf("notes of task %d: %w", task.ID, err)
+			}
+			notes[task.ID] = list
+			tasks[i].Notes = list
+		}
+	}
+
+	if *output == "" {
+		return export(a.stdout, *as, checklist, tasks, notes)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := export(f, *as, checklist, tasks, notes); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// export writes the checklist in the given format.
+func export(w io.Writer, format string, checklist *checkvist.Checklist, tasks []checkvist.Task, notes map[int][]checkvist.Note) error {
+	switch strings.ToLower(format) {
+	case "markdown", "md":
+		return checkvist.ExportMarkdown(w, *checklist, tasks, notes)
+	case "opml":
+		return checkvist.ExportOPML(w, *checklist, tasks, notes)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(checkvist.ChecklistBackup{Checklist: *checklist, Tasks: tasks})
+	}
+	return fmt.Errorf("%w: unknown export format %q", errUsage, format)
+}
+
+// checklist creates the client and resolves the checklist argument.
+func (a *app) checklist(ctx context.Context, ref string) (*checkvist.Client, *checkvist.Checklist, error) {
+	client, err := a.newClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	checklist, err := a.resolveChecklist(ctx, client, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, checklist, nil
+}
+
+// taskArgs parses the "<list> <task>..." arguments shared by several commands.
+func (a *app) taskArgs(ctx context.Context, name string, args []string) (*checkvist.Client, *checkvist.Checklist, []int, error) {
+	positional, err := parse(a.flags(name), args, 2, -1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var ids []int
+	for _, arg := range positional[1:] {
+		id, err := parseID(arg)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		ids = append(ids, id)
+	}
+	client, checklist, err := a.checklist(ctx, positional[0])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return client, checklist, ids, nil
+}
+
+// parseID parses a positive numeric ID.
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid ID %q", errUsage, s)
+	}
+	return id, nil
+}
+
+// parseStatus parses a task status name.
+func parseStatus(s string) (checkvist.TaskStatus, error) {
+	switch strings.ToLower(s) {
+	case "open":
+		return checkvist.StatusOpen, nil
+	case "closed", "done":
+		return checkvist.StatusClosed, nil
+	case "invalidated":
+		return checkvist.StatusInvalidated, nil
+	}
+	return 0, fmt.Errorf("%w: unknown status %q", errUsage, s)
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(s string) []string {
+	var result []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// formatDue formats the due date of a task for output.
+func formatDue(task checkvist.Task) string {
+	if task.DueDate != nil {
+		return task.DueDate.Format(time.DateOnly)
+	}
+	return task.DueDateRaw
+}
@@ -0,0 +1,321 @@
+// Command checkvist is a command-line client for Checkvist.
+//
+// Usage:
+//
+//	checkvist [-format table|json|plain] [-config file] <command> [flags] [args]
+//
+// Credentials are read from the CHECKVIST_USERNAME and CHECKVIST_REMOTE_KEY
+// environment variables or from a JSON config file, by default
+// checkvist/config.json in the user's config directory:
+//
+//	{"username": "user@example.com", "remote_key": "..."}
+//
+// Environment variables take precedence over the config file. After
+// "checkvist login", the token is cached so later commands do not need to
+// log in again.
+//
+// Checklists can be given by ID or by name.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+// main.go contains the entry point, configuration loading and command dispatch.
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
+
+// errUsage indicates invalid command-line arguments.
+var errUsage = errors.New("invalid usage")
+
+// command is a subcommand of the CLI.
+type command struct {
+	usage string
+	run   func(ctx context.Context, a *app, args []string) error
+}
+
+var commands = map[string]command{
+	"login":      {"login [-totp code]", cmdLogin},
+	"lists":      {"lists [-archived]", cmdLists},
+	"show":       {"show <list> [-q query] [-tag t,...] [-status s] [-search text] [-overdue]", cmdShow},
+	"add":        {"add <list> <content> [-parent id] [-position n] [-due date] [-priority n] [-tags t,...]", cmdAdd},
+	"done":       {"done <list> <task>...", statusCommand((*checkvist.TaskService).Close)},
+	"reopen":     {"reopen <list> <task>...", statusCommand((*checkvist.TaskService).Reopen)},
+	"invalidate": {"invalidate <list> <task>...", statusCommand((*checkvist.TaskService).Invalidate)},
+	"rm":         {"rm <list> <task>...", cmdRemove},
+	"note":       {"note <list> <task> [text]", cmdNote},
+	"tag":        {"tag <list> <task> [tag...] [-remove t,...]", cmdTag},
+	"due":        {"due <list> <task> <date|none>", cmdDue},
+	"export":     {"export <list> [-as markdown|opml|json] [-notes] [-o file]", cmdExport},
+}
+
+// config holds the credentials and settings of the CLI.
+type config struct {
+	Username  string `json:"username"`
+	RemoteKey string `json:"remote_key"`
+	BaseURL   string `json:"base_url,omitempty"`
+	TokenDir  string `json:"token_dir,omitempty"`
+}
+
+// app holds the state shared by the commands.
+type app struct {
+	env        func(string) string
+	stdout     io.Writer
+	stderr     io.Writer
+	format     string
+	configPath string
+	client     *checkvist.Client
+}
+
+// run executes the CLI and returns the exit code.
+func run(ctx context.Context, args []string, env func(string) string, stdout, stderr io.Writer) int {
+	a := &app{env: env, stdout: stdout, stderr: stderr}
+
+	global := flag.NewFlagSet("checkvist", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	a.format = "table"
+	a.formatFlag(global)
+	global.StringVar(&a.configPath, "config", "", "path to the config file")
+	global.Usage = func() { a.usage() }
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	if global.NArg() == 0 {
+		a.usage()
+		return 2
+	}
+
+	name := global.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "checkvist: unknown command %q\n", name)
+		a.usage()
+		return 2
+	}
+
+	if err := cmd.run(ctx, a, global.Args()[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(stderr, "checkvist %s: %v\n", name, err)
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(stderr, "usage: checkvist %s\n", cmd.usage)
+			return 2
+		}
+		return 1
+	}
+	return 0
+}
+
+// usage prints the list of commands.
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "usage: checkvist [-format table|json|plain] [-config file] <command> [flags] [args]")
+	fmt.Fprintln(a.stderr, "\ncommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(a.stderr, "  %s\n", commands[name].usage)
+	}
+}
+
+// flags creates a flag set for a command that also accepts -format.
+func (a *app) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	a.formatFlag(fs)
+	return fs
+}
+
+// formatFlag registers the -format flag, which is validated before any
+// command runs.
+func (a *app) formatFlag(fs *flag.FlagSet) {
+	fs.Func("format", "output format: table, json or plain (default table)", func(s string) error {
+		switch s {
+		case "table", "json", "plain":
+			a.format = s
+			return nil
+		}
+		return fmt.Errorf("unknown output format %q", s)
+	})
+}
+
+// parse parses flags and positional arguments in any order and checks the
+// number of positional arguments. Arguments after "--" are positional, even
+// if they start with "-".
+func parse(fs *flag.FlagSet, args []string, min, max int) ([]string, error) {
+	var positional, rest []string
+	if i := terminator(fs, args); i >= 0 {
+		args, rest = args[:i], args[i+1:]
+	}
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+		if args = fs.Args(); len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	positional = append(positional, rest...)
+	if len(positional) < min || (max >= 0 && len(positional) > max) {
+		return nil, fmt.Errorf("%w: expected %s", errUsage, argCount(min, max))
+	}
+	return positional, nil
+}
+
+// terminator returns the index of the "--" that ends the flags, or -1. A
+// "--" that is the value of a preceding flag, as in "-due --", does not count.
+func terminator(fs *flag.FlagSet, args []string) int {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return i
+		}
+		if len(arg) < 2 || arg[0] != '-' || strings.Contains(arg, "=") {
+			continue
+		}
+		f := fs.Lookup(strings.TrimLeft(arg, "-"))
+		if f == nil {
+			continue
+		}
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			continue
+		}
+		i++ // skip the flag's value
+	}
+	return -1
+}
+
+// argCount describes the expected number of positional arguments.
+func argCount(min, max int) string {
+	switch {
+	case min == max:
+		return fmt.Sprintf("%d arguments", min)
+	case max < 0:
+		return fmt.Sprintf("at least %d arguments", min)
+	default:
+		return fmt.Sprintf("%d to %d arguments", min, max)
+	}
+}
+
+// loadConfig reads the config file, if any, and applies the environment.
+func (a *app) loadConfig() (config, error) {
+	var cfg config
+
+	path := a.configPath
+	if path == "" {
+		path = a.env("CHECKVIST_CONFIG")
+	}
+	explicit := path != ""
+	if !explicit {
+		if dir, err := os.UserConfigDir(); err == nil {
+			path = filepath.Join(dir, "checkvist", "config.json")
+		}
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("reading config %s: %w", path, err)
+			}
+		case explicit || !errors.Is(err, os.ErrNotExist):
+			return cfg, fmt.Errorf("reading config: %w", err)
+		}
+	}
+
+	for _, v := range []struct {
+		name  string
+		value *string
+	}{
+		{"CHECKVIST_USERNAME", &cfg.Username},
+		{"CHECKVIST_REMOTE_KEY", &cfg.RemoteKey},
+		{"CHECKVIST_BASE_URL", &cfg.BaseURL},
+		{"CHECKVIST_TOKEN_DIR", &cfg.TokenDir},
+	} {
+		if s := a.env(v.name); s != "" {
+			*v.value = s
+		}
+	}
+
+	if cfg.Username == "" || cfg.RemoteKey == "" {
+		return cfg, errors.New("missing credentials: set CHECKVIST_USERNAME and CHECKVIST_REMOTE_KEY or create a config file")
+	}
+	return cfg, nil
+}
+
+// newClient creates the API client from the configuration.
+func (a *app) newClient(opts ...checkvist.Option) (*checkvist.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.BaseURL != "" {
+		opts = append(opts, checkvist.WithBaseURL(cfg.BaseURL))
+	}
+	dir := cfg.TokenDir
+	if dir == "" {
+		if dir, err = checkvist.DefaultTokenStoreDir(); err != nil {
+			dir = ""
+		}
+	}
+	if dir != "" {
+		opts = append(opts, checkvist.WithTokenStore(checkvist.NewFileTokenStore(dir)))
+	}
+
+	a.client = checkvist.NewClient(cfg.Username, cfg.RemoteKey, opts...)
+	return a.client, nil
+}
+
+// resolveChecklist finds a checklist by ID or by case-insensitive name.
+func (a *app) resolveChecklist(ctx context.Context, client *checkvist.Client, ref string) (*checkvist.Checklist, error) {
+	if id, err := parseID(ref); err == nil {
+		return client.Checklists().Get(ctx, id)
+	}
+
+	checklists, err := client.Checklists().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var matches []checkvist.Checklist
+	for _, cl := range checklists {
+		if strings.EqualFold(cl.Name, ref) {
+			matches = append(matches, cl)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("checklist %q not found", ref)
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("checklist name %q is ambiguous; use its ID", ref)
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+	"code.beautifulmachines.dev/jakoubek/checkvist-api/checkvisttest"
+)
+
+// testEnv returns an environment that points the CLI at the fake server.
+func testEnv(t *testing.T, server *checkvisttest.Server) map[string]string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return map[string]string{
+		"CHECKVIST_USERNAME":   checkvisttest.DefaultUsername,
+		"CHECKVIST_REMOTE_KEY": checkvisttest.DefaultRemoteKey,
+		"CHECKVIST_BASE_URL":   server.URL,
+		"CHECKVIST_TOKEN_DIR":  t.TempDir(),
+		"CHECKVIST_CONFIG":     path,
+	}
+}
+
+// runCLI runs the CLI and returns its exit code and output.
+func runCLI(env map[string]string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	getenv := func(key string) string { return env[key] }
+	code := run(context.Background(), args, getenv, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCLI_Lists(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	env := testEnv(t, server)
+	server.AddChecklist("Groceries")
+	server.AddChecklist("Work")
+
+	code, stdout, stderr := runCLI(env, "lists")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Groceries") {
+		t.Errorf("unexpected table output:\n%s", stdout)
+	}
+
+	code, stdout, _ = runCLI(env, "-format", "json", "lists")
+	var checklists []checkvist.Checklist
+	if code != 0 || json.Unmarshal([]byte(stdout), &checklists) != nil || len(checklists) != 2 {
+		t.Errorf("unexpected JSON output:\n%s", stdout)
+	}
+
+	code, stdout, _ = runCLI(env, "lists", "-format", "plain")
+	if code != 0 || strings.Contains(stdout, "NAME") || strings.Count(stdout, "\n") != 2 {
+		t.Errorf("unexpected plain output:\n%s", stdout)
+	}
+}
+
+func TestCLI_TaskLifecycle(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	env := testEnv(t, server)
+	cl := server.AddChecklist("Work")
+
+	code, stdout, stderr := runCLI(env, "-format", "json", "add", "work", "Write", "report", "-tags", "urgent,q4", "-due", "2026-11-01")
+	if code != 0 {
+		t.Fatalf("add: exit code %d: %s", code, stderr)
+	}
+	var added []checkvist.Task
+	if err := json.Unmarshal([]byte(stdout), &added); err != nil || len(added) != 1 {
+		t.Fatalf("add: unexpected output %q", stdout)
+	}
+	task := added[0]
+	if task.Content != "Write report" || task.DueDateRaw != "2026/11/01" {
+		t.Errorf("add: unexpected task %+v", task)
+	}
+	id := strconv.Itoa(task.ID)
+
+	if code, _, stderr := runCLI(env, "add", strconv.Itoa(cl.ID), "Outline", "-parent", id); code != 0 {
+		t.Fatalf("add subtask: exit code %d: %s", code, stderr)
+	}
+	if code, _, stderr := runCLI(env, "tag", "Work", id, "review", "-remove", "q4"); code != 0 {
+		t.Fatalf("tag: exit code %d: %s", code, stderr)
+	}
+	if code, _, stderr := runCLI(env, "due", "Work", id, "none"); code != 0 {
+		t.Fatalf("due: exit code %d: %s", code, stderr)
+	}
+	if code, _, stderr := runCLI(env, "note", "Work", id, "Draft", "sent"); code != 0 {
+		t.Fatalf("note: exit code %d: %s", code, stderr)
+	}
+
+	tasks := server.Tasks(cl.ID)
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+	got := tasks[0]
+	if got.TagsAsText != "review, urgent" || got.DueDateRaw != "" {
+		t.Errorf("unexpected task after tag and due: %+v", got)
+	}
+	if notes := server.Notes(task.ID); len(notes) != 1 || notes[0].Comment != "Draft sent" {
+		t.Errorf("unexpected notes: %+v", notes)
+	}
+
+	code, stdout, _ = runCLI(env, "show", "Work")
+	if code != 0 || !strings.Contains(stdout, "Write report") || !strings.Contains(stdout, "  Outline") {
+		t.Errorf("show: unexpected output:\n%s", stdout)
+	}
+
+	if code, _, stderr := runCLI(env, "done", "Work", id); code != 0 {
+		t.Fatalf("done: exit code %d: %s", code, stderr)
+	}
+	code, stdout, _ = runCLI(env, "-format", "plain", "show", "Work", "-status", "open")
+	if code != 0 || stdout != "" {
+		t.Errorf("show open: expected no open tasks, got:\n%s", stdout)
+	}
+	if code, _, stderr := runCLI(env, "reopen", "Work", id); code != 0 {
+		t.Fatalf("reopen: exit code %d: %s", code, stderr)
+	}
+	code, stdout, _ = runCLI(env, "-format", "plain", "show", "Work", "-tag", "review")
+	if code != 0 || strings.Count(stdout, "\n") != 1 || !strings.Contains(stdout, "open") {
+		t.Errorf("show tag: unexpected output:\n%s", stdout)
+	}
+
+	code, stdout, _ = runCLI(env, "-format", "plain", "show", "Work", "-q", "#urgent OR outline")
+	if code != 0 || strings.Count(stdout, "\n") != 2 {
+		t.Errorf("show query: unexpected output:\n%s", stdout)
+	}
+
+	if code, _, stderr := runCLI(env, "rm", "Work", id); code != 0 {
+		t.Fatalf("rm: exit code %d: %s", code, stderr)
+	}
+	if tasks := server.Tasks(cl.ID); len(tasks) != 0 {
+		t.Errorf("expected no tasks after rm, got %+v", tasks)
+	}
+}
+
+func TestCLI_DashDashEndsFlags(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	env := testEnv(t, server)
+	cl := server.AddChecklist("Work")
+
+	if code, _, stderr := runCLI(env, "add", "Work", "-priority", "1", "--", "-a", "-b"); code != 0 {
+		t.Fatalf("add: exit code %d: %s", code, stderr)
+	}
+	tasks := server.Tasks(cl.ID)
+	if len(tasks) != 1 || tasks[0].Content != "-a -b" || tasks[0].Priority != 1 {
+		t.Errorf("expected task \"-a -b\" with priority 1, got %+v", tasks)
+	}
+
+	// A "--" that is the value of a flag does not end the flags.
+	if code, _, stderr := runCLI(env, "add", "Work", "-tags", "--", "x", "-priority", "2"); code != 0 {
+		t.Fatalf("add with flag value: exit code %d: %s", code, stderr)
+	}
+	tasks = server.Tasks(cl.ID)
+	if len(tasks) != 2 || tasks[1].Content != "x" || tasks[1].TagsAsText != "--" || tasks[1].Priority != 2 {
+		t.Errorf("expected task \"x\" tagged \"--\" with priority 2, got %+v", tasks)
+	}
+}
+
+func TestCLI_Export(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	env := testEnv(t, server)
+	cl := server.AddChecklist("Trip")
+	task := server.AddTask(cl.ID, checkvist.Task{Content: "Book hotel"})
+	server.AddNote(task.ID, "Near the station")
+
+	out := filepath.Join(t.TempDir(), "trip.md")
+	code, _, stderr := runCLI(env, "export", "Trip", "-notes", "-o", out)
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Book hotel") || !strings.Contains(string(data), "Near the station") {
+		t.Errorf("unexpected export:\n%s", data)
+	}
+
+	code, stdout, _ := runCLI(env, "export", "Trip", "-as", "opml")
+	if code != 0 || !strings.Contains(stdout, "<opml") {
+		t.Errorf("unexpected OPML export:\n%s", stdout)
+	}
+}
+
+func TestCLI_ConfigFile(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := config{
+		Username:  checkvisttest.DefaultUsername,
+		RemoteKey: checkvisttest.DefaultRemoteKey,
+		BaseURL:   server.URL,
+		TokenDir:  t.TempDir(),
+	}
+	data, _ := json.Marshal(cfg)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI(nil, "-config", path, "login")
+	if code != 0 {
+		t.Fatalf("exit code %d: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, checkvisttest.DefaultUsername) {
+		t.Errorf("expected user in output, got:\n%s", stdout)
+	}
+	if entries, _ := os.ReadDir(cfg.TokenDir); len(entries) == 0 {
+		t.Error("expected token to be cached")
+	}
+}
+
+func TestCLI_Errors(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	env := testEnv(t, server)
+	server.AddChecklist("Work")
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		code int
+		want string
+	}{
+		{"no command", env, nil, 2, "usage:"},
+		{"unknown command", env, []string{"frobnicate"}, 2, "unknown command"},
+		{"bad format", env, []string{"-format", "xml", "lists"}, 2, "unknown output format"},
+		{"missing args", env, []string{"add", "Work"}, 2, "usage: checkvist add"},
+		{"bad task ID", env, []string{"done", "Work", "abc"}, 2, "invalid ID"},
+		{"unknown list", env, []string{"show", "Nope"}, 1, "not found"},
+		{"bad query", env, []string{"show", "Work", "-q", "(foo"}, 2, "invalid query at column 1"},
+		{"bad export format", env, []string{"export", "Work", "-as", "pdf"}, 2, "unknown export format"},
+		{"missing credentials", map[string]string{"CHECKVIST_CONFIG": env["CHECKVIST_CONFIG"]}, []string{"lists"}, 1, "missing credentials"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCLI(tt.env, tt.args...)
+			if code != tt.code || !strings.Contains(stderr, tt.want) {
+				t.Errorf("got exit code %d and stderr %q, want %d and %q", code, stderr, tt.code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	checkvist "code.beautifulmachines.dev/jakoubek/checkvist-api"
+)
+
+// output.go contains the table, JSON and plain-text output formats.
+
+// print writes v as JSON, or the rows as a table or as tab-separated plain
+// text without a header, depending on the -format flag.
+func (a *app) print(v any, header []string, rows [][]string) error {
+	switch a.format {
+	case "json":
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(a.stdout, "%s\n", data)
+		return err
+	case "plain":
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(a.stdout, strings.Join(row, "\t")); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// printChecklists prints a list of checklists.
+func (a *app) printChecklists(checklists []checkvist.Checklist) error {
+	rows := make([][]string, 0, len(checklists))
+	for _, cl := range checklists {
+		rows = append(rows, []string{
+			strconv.Itoa(cl.ID),
+			cl.Name,
+			fmt.Sprintf("%d/%d", cl.TaskCompleted, cl.TaskCount),
+			cl.Tags.String(),
+		})
+	}
+	if checklists == nil {
+		checklists = []checkvist.Checklist{}
+	}
+	return a.print(checklists, []string{"ID", "NAME", "DONE", "TAGS"}, rows)
+}
+
+// printTasks prints a list of tasks. If tree is not nil, the tasks are
+// printed in outline order with their content indented by depth.
+func (a *app) printTasks(tasks []checkvist.Task, tree *checkvist.TaskTree) error {
+	depth := make(map[int]int)
+	if tree != nil {
+		selected := make(map[int]bool, len(tasks))
+		for _, task := range tasks {
+			selected[task.ID] = true
+		}
+		tasks = tasks[:0:0]
+		tree.Walk(func(node *checkvist.TaskNode) bool {
+			if selected[node.Task.ID] {
+				tasks = append(tasks, node.Task)
+				depth[node.Task.ID] = node.Depth()
+			}
+			return true
+		})
+	}
+
+	rows := make([][]string, 0, len(tasks))
+	for _, task := range tasks {
+		content := task.Content
+		if a.format == "table" {
+			content = strings.Repeat("  ", depth[task.ID]) + content
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(task.ID),
+			task.Status.String(),
+			formatDue(task),
+			content,
+			task.Tags.String(),
+		})
+	}
+	if tasks == nil {
+		tasks = []checkvist.Task{}
+	}
+	return a.print(tasks, []string{"ID", "STATUS", "DUE", "CONTENT", "TAGS"}, rows)
+}
+
+// printNotes prints a list of notes.
+func (a *app) printNotes(notes []checkvist.Note) error {
+	rows := make([][]string, 0, len(notes))
+	for _, note := range notes {
+		rows = append(rows, []string{
+			strconv.Itoa(note.ID),
+			note.UpdatedAt.Format("2006-01-02 15:04"),
+			note.Comment,
+		})
+	}
+	if notes == nil {
+		notes = []checkvist.Note{}
+	}
+	return a.print(notes, []string{"ID", "UPDATED", "COMMENT"}, rows)
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // errors.go contains the APIError type and sentinel errors for common API error conditions.
@@ -21,6 +24,9 @@ var (
 	ErrBadRequest = errors.New("bad request: invalid parameters")
 	// ErrServerError is returned for server-side errors (HTTP 5xx).
 	ErrServerError = errors.New("server error: the server encountered an error")
+	// ErrConflict is returned when a resource was modified concurrently
+	// and an optimistic update was rejected.
+	ErrConflict = errors.New("conflict: the resource was modified concurrently")
 )
 
 // APIError represents an error returned by the Checkvist API.
@@ -33,6 +39,17 @@ type APIError struct {
 	RequestID string
 	// Err is the underlying sentinel error, if applicable.
 	Err error
+	// RetryAfter is the delay requested by the server via the Retry-After
+	// header, or zero if the header was absent.
+	RetryAfter time.Duration
+	// Limit is the request limit from the X-RateLimit-Limit header, or zero if absent.
+	Limit int
+	// Remaining is the number of requests left from the X-RateLimit-Remaining
+	// header, or zero if absent.
+	Remaining int
+	// Reset is the time the rate limit resets from the X-RateLimit-Reset
+	// header, or the zero time if absent.
+	Reset time.Time
 }
 
 // Error implements the error interface.
@@ -59,6 +76,10 @@ func NewAPIError(resp *http.Response, message string) *APIError {
 		StatusCode: resp.StatusCode,
 		Message:    message,
 		RequestID:  resp.Header.Get("X-Request-Id"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Limit:      parseHeaderInt(resp.Header.Get("X-RateLimit-Limit")),
+		Remaining:  parseHeaderInt(resp.Header.Get("X-RateLimit-Remaining")),
+		Reset:      parseRateLimitReset(resp.Header.Get("X-RateLimit-Reset"), time.Now()),
 	}
 
 	// Map status codes to sentinel errors
@@ -79,3 +100,59 @@ func NewAPIError(resp *http.Response, message string) *APIError {
 
 	return apiErr
 }
+
+// retryDelay returns the delay the server asked for before the next attempt:
+// the Retry-After value if present, otherwise the time until the rate limit
+// resets for HTTP 429 responses. It returns zero if there is no hint.
+func (e *APIError) retryDelay(now time.Time) time.Duration {
+	if e.RetryAfter > 0 {
+		return e.RetryAfter
+	}
+	if e.StatusCode == http.StatusTooManyRequests && e.Reset.After(now) {
+		return e.Reset.Sub(now)
+	}
+	return 0
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a number
+// of seconds or an HTTP date. Invalid or past values yield zero.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// parseRateLimitReset parses an X-RateLimit-Reset header value. Values large
+// enough to be Unix timestamps are treated as such; smaller values are
+// seconds from now.
+func parseRateLimitReset(value string, now time.Time) time.Time {
+	n := parseHeaderInt(value)
+	if n <= 0 {
+		return time.Time{}
+	}
+	if n >= 1_000_000_000 {
+		return time.Unix(int64(n), 0)
+	}
+	return now.Add(time.Duration(n) * time.Second)
+}
+
+// parseHeaderInt parses an integer header value, returning zero if it is
+// absent or invalid.
+func parseHeaderInt(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -1,7 +1,6 @@
 package checkvist
 
 import (
-	"strings"
 	"time"
 )
 
@@ -10,9 +9,13 @@ import (
 // is performed locally after fetching all tasks.
 
 // Filter provides a builder pattern for filtering tasks client-side.
+// Conditions added by the builder methods and Where must all match.
 type Filter struct {
-	tasks   []Task
-	filters []func(Task) bool
+	tasks            []Task
+	filters          []Predicate
+	hierarchy        []func(*taskIndex) Predicate
+	includeAncestors bool
+	sortKeys         []SortKey
 }
 
 // NewFilter creates a new Filter with the given tasks.
@@ -20,133 +23,120 @@ func NewFilter(tasks []Task) *Filter {
 	return &Filter{tasks: tasks}
 }
 
+// Where adds predicates that tasks must match. Use And, Or and Not to
+// express conditions the builder methods cannot, for example:
+//
+//	f.Where(checkvist.Or(checkvist.MatchTag("urgent"), checkvist.MatchOverdue()))
+func (f *Filter) Where(preds ...Predicate) *Filter {
+	f.filters = append(f.filters, preds...)
+	return f
+}
+
+// Predicate returns the conditions of the filter as a single predicate,
+// so that a filter, such as one returned by ParseQuery, can be combined
+// with others. Hierarchy conditions are resolved against the tasks the
+// Filter was created with; IncludeAncestors and SortBy do not apply.
+func (f *Filter) Predicate() Predicate {
+	var idx *taskIndex
+	if len(f.hierarchy) > 0 {
+		idx = newTaskIndex(f.tasks)
+	}
+	return And(f.conditions(idx)...)
+}
+
+// conditions returns the predicates and the hierarchy conditions resolved
+// against idx.
+func (f *Filter) conditions(idx *taskIndex) []Predicate {
+	preds := append([]Predicate(nil), f.filters...)
+	for _, resolve := range f.hierarchy {
+		preds = append(preds, resolve(idx))
+	}
+	return preds
+}
+
 // WithTag filters tasks that have the specified tag.
 func (f *Filter) WithTag(tag string) *Filter {
-	f.filters = append(f.filters, func(t Task) bool {
-		return taskHasTag(t, tag)
-	})
-	return f
+	return f.Where(MatchTag(tag))
 }
 
 // WithTags filters tasks that have all of the specified tags (AND logic).
 func (f *Filter) WithTags(tags ...string) *Filter {
-	f.filters = append(f.filters, func(t Task) bool {
-		for _, tag := range tags {
-			if !taskHasTag(t, tag) {
-				return false
-			}
-		}
-		return true
-	})
-	return f
+	return f.Where(MatchTags(tags...))
 }
 
 // WithStatus filters tasks by their status.
 func (f *Filter) WithStatus(status TaskStatus) *Filter {
-	f.filters = append(f.filters, func(t Task) bool {
-		return t.Status == status
-	})
-	return f
+	return f.Where(MatchStatus(status))
 }
 
 // WithDueBefore filters tasks with due dates before the specified time.
 func (f *Filter) WithDueBefore(deadline time.Time) *Filter {
-	f.filters = append(f.filters, func(t Task) bool {
-		if t.DueDate == nil {
-			return false
-		}
-		return t.DueDate.Before(deadline)
-	})
-	return f
+	return f.Where(MatchDueBefore(deadline))
 }
 
 // WithDueAfter filters tasks with due dates after the specified time.
 func (f *Filter) WithDueAfter(after time.Time) *Filter {
-	f.filters = append(f.filters, func(t Task) bool {
-		if t.DueDate == nil {
-			return false
-		}
-		return t.DueDate.After(after)
-	})
-	return f
+	return f.Where(MatchDueAfter(after))
 }
 
 // WithDueOn filters tasks with due dates on the specified day.
 func (f *Filter) WithDueOn(day time.Time) *Filter {
-	year, month, d := day.Date()
-	f.filters = append(f.filters, func(t Task) bool {
-		if t.DueDate == nil {
-			return false
-		}
-		ty, tm, td := t.DueDate.Date()
-		return ty == year && tm == month && td == d
-	})
-	return f
+	return f.Where(MatchDueOn(day))
 }
 
-// WithOverdue filters tasks that are overdue (due date is before today).
+// WithOverdue filters open tasks that are overdue (due date is before today
+// in the local time zone).
 func (f *Filter) WithOverdue() *Filter {
-	today := time.Now().Truncate(24 * time.Hour)
-	f.filters = append(f.filters, func(t Task) bool {
-		if t.DueDate == nil {
-			return false
-		}
-		return t.DueDate.Before(today) && t.Status == StatusOpen
-	})
-	return f
+	return f.Where(MatchOverdue())
 }
 
 // WithSearch filters tasks whose content contains the search query (case-insensitive).
 func (f *Filter) WithSearch(query string) *Filter {
-	lowerQuery := strings.ToLower(query)
-	f.filters = append(f.filters, func(t Task) bool {
-		return strings.Contains(strings.ToLower(t.Content), lowerQuery)
-	})
-	return f
+	return f.Where(MatchSearch(query))
 }
 
-// Apply applies all filters and returns the filtered tasks.
+// Apply applies all filters and returns the filtered tasks, sorted by the
+// keys given to SortBy if any.
 func (f *Filter) Apply() []Task {
-	if len(f.filters) == 0 {
-		result := make([]Task, len(f.tasks))
-		copy(result, f.tasks)
-		return result
-	}
+	return f.ApplyTo(f.tasks)
+}
 
-	result := make([]Task, 0, len(f.tasks))
-	for _, task := range f.tasks {
-		if f.matches(task) {
-			result = append(result, task)
-		}
+// ApplyTo applies all filters to the given tasks instead of the tasks the
+// Filter was created with. This allows a Filter, such as one returned by
+// ParseQuery, to be reused for several task lists. Hierarchy conditions are
+// resolved against the given tasks.
+func (f *Filter) ApplyTo(tasks []Task) []Task {
+	var idx *taskIndex
+	if len(f.hierarchy) > 0 || f.includeAncestors {
+		idx = newTaskIndex(tasks)
 	}
-	return result
-}
 
-// matches checks if a task matches all filters.
-func (f *Filter) matches(task Task) bool {
-	for _, filter := range f.filters {
-		if !filter(task) {
-			return false
+	var result []Task
+	if preds := f.conditions(idx); len(preds) == 0 {
+		result = make([]Task, len(tasks))
+		copy(result, tasks)
+	} else {
+		match := And(preds...)
+		result = make([]Task, 0, len(tasks))
+		for _, task := range tasks {
+			if match(task) {
+				result = append(result, task)
+			}
+		}
+		if f.includeAncestors {
+			result = idx.withAncestors(tasks, result)
 		}
 	}
-	return true
+	SortTasks(result, f.sortKeys...)
+	return result
 }
 
 // taskHasTag checks if a task has a specific tag.
 func taskHasTag(t Task, tag string) bool {
 	// Check parsed Tags map first
-	if t.Tags != nil && t.Tags[tag] {
-		return true
-	}
-	// Fall back to checking TagsAsText
-	if t.TagsAsText == "" {
-		return false
-	}
-	lowerTag := strings.ToLower(tag)
-	for _, part := range strings.Split(t.TagsAsText, ",") {
-		if strings.ToLower(strings.TrimSpace(part)) == lowerTag {
-			return true
-		}
+	if t.Tags != nil {
+		return t.Tags.Has(tag)
 	}
-	return false
+	// Fall back to parsing TagsAsText for tasks not decoded by a service
+	return ParseTags(t.TagsAsText).Has(tag)
 }
@@ -130,6 +130,41 @@ func TestFilter_WithDueBefore(t *testing.T) {
 	}
 }
 
+func TestOverdueAt_LocalDay(t *testing.T) {
+	// Early morning of October 16 east of UTC, still October 15 in UTC.
+	now := time.Date(2026, 10, 16, 1, 0, 0, 0, time.FixedZone("UTC+10", 10*60*60))
+	yesterday := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	overdue := overdueAt(now)
+	if !overdue(Task{DueDate: &yesterday}) {
+		t.Error("expected task due on the previous local day to be overdue")
+	}
+	if overdue(Task{DueDate: &today}) {
+		t.Error("expected task due on the local day not to be overdue")
+	}
+}
+
+func TestMatchOverdue_MatchesQuery(t *testing.T) {
+	today := startOfDay(time.Now())
+	yesterday := today.AddDate(0, 0, -1)
+	tasks := []Task{
+		{ID: 1, DueDate: &yesterday},
+		{ID: 2, DueDate: &today},
+		{ID: 3, DueDate: &yesterday, Status: StatusClosed},
+	}
+
+	query, err := ParseQuery("due:overdue")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fromQuery := query.ApplyTo(tasks)
+	fromFilter := NewFilter(tasks).WithOverdue().Apply()
+	if len(fromQuery) != 1 || len(fromFilter) != 1 || fromQuery[0].ID != 1 || fromFilter[0].ID != 1 {
+		t.Errorf("expected both to match task 1, got query %v and filter %v", fromQuery, fromFilter)
+	}
+}
+
 func TestFilter_WithDueAfter(t *testing.T) {
 	now := time.Now()
 	yesterday := now.AddDate(0, 0, -1)
@@ -177,7 +212,7 @@ func TestFilter_WithDueOn(t *testing.T) {
 }
 
 func TestFilter_WithOverdue(t *testing.T) {
-	today := time.Now().Truncate(24 * time.Hour)
+	today := startOfDay(time.Now())
 	yesterday := today.AddDate(0, 0, -1)
 	tomorrow := today.AddDate(0, 0, 1)
 
@@ -336,3 +371,69 @@ func TestFilter_TagsMap(t *testing.T) {
 		t.Errorf("expected 2 tasks, got %d", len(result))
 	}
 }
+
+func TestFilter_Where(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -2)
+	tasks := []Task{
+		{ID: 1, Content: "Task 1", TagsAsText: "urgent"},
+		{ID: 2, Content: "Task 2", TagsAsText: "someday", DueDate: &yesterday},
+		{ID: 3, Content: "Task 3", TagsAsText: "urgent, someday", Status: StatusClosed},
+		{ID: 4, Content: "Task 4", TagsAsText: ""},
+	}
+
+	tests := []struct {
+		name     string
+		preds    []Predicate
+		expected []int
+	}{
+		{"or", []Predicate{Or(MatchTag("urgent"), MatchOverdue())}, []int{1, 2, 3}},
+		{"not", []Predicate{Not(MatchTag("someday"))}, []int{1, 4}},
+		{"nested", []Predicate{And(MatchStatus(StatusOpen), Or(MatchTag("urgent"), Not(MatchTag("someday"))))}, []int{1, 4}},
+		{"multiple predicates are ANDed", []Predicate{MatchTag("urgent"), MatchTag("someday")}, []int{3}},
+		{"empty and", []Predicate{And()}, []int{1, 2, 3, 4}},
+		{"empty or", []Predicate{Or()}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewFilter(tasks).Where(tt.preds...).Apply()
+			if len(result) != len(tt.expected) {
+				t.Fatalf("expected tasks %v, got %+v", tt.expected, result)
+			}
+			for i, task := range result {
+				if task.ID != tt.expected[i] {
+					t.Errorf("expected task ID %d at index %d, got %d", tt.expected[i], i, task.ID)
+				}
+			}
+		})
+	}
+
+	// The builder methods and Where combine with AND.
+	result := NewFilter(tasks).WithStatus(StatusOpen).Where(Not(MatchTag("urgent"))).Apply()
+	if len(result) != 2 || result[0].ID != 2 || result[1].ID != 4 {
+		t.Errorf("expected tasks 2 and 4, got %+v", result)
+	}
+}
+
+func TestFilter_Predicate(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, Content: "Write report", TagsAsText: "urgent"},
+		{ID: 2, Content: "Review", TagsAsText: "work"},
+		{ID: 3, Content: "Call", TagsAsText: ""},
+	}
+
+	query, err := ParseQuery("#urgent report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	work := NewFilter(nil).WithTag("work").Predicate()
+
+	result := NewFilter(tasks).Where(Or(query.Predicate(), work)).Apply()
+	if len(result) != 2 || result[0].ID != 1 || result[1].ID != 2 {
+		t.Errorf("expected tasks 1 and 2, got %+v", result)
+	}
+
+	if all := NewFilter(nil).Predicate(); !all(tasks[2]) {
+		t.Error("expected an empty filter's predicate to match every task")
+	}
+}
@@ -0,0 +1,160 @@
+package checkvist
+
+// hierarchy.go contains the hierarchy-aware Filter conditions. Unlike
+// predicates, they depend on the other tasks in the list, so they are
+// resolved against the list passed to Apply or ApplyTo.
+
+// WithDescendantsOf filters tasks below the given task at any depth.
+// The task itself is not included.
+func (f *Filter) WithDescendantsOf(taskID int) *Filter {
+	f.hierarchy = append(f.hierarchy, func(idx *taskIndex) Predicate {
+		descendants := idx.descendants(taskID)
+		return func(t Task) bool { return descendants[t.ID] }
+	})
+	return f
+}
+
+// WithAncestorsOf filters the parent chain of the given task, up to its root.
+// The task itself is not included.
+func (f *Filter) WithAncestorsOf(taskID int) *Filter {
+	f.hierarchy = append(f.hierarchy, func(idx *taskIndex) Predicate {
+		ancestors := make(map[int]bool)
+		for _, id := range idx.ancestors(taskID) {
+			ancestors[id] = true
+		}
+		return func(t Task) bool { return ancestors[t.ID] }
+	})
+	return f
+}
+
+// OnlyLeaves filters tasks without children. A task has children if its
+// ChildIDs is non-empty or another task in the list names it as parent.
+func (f *Filter) OnlyLeaves() *Filter {
+	f.hierarchy = append(f.hierarchy, func(idx *taskIndex) Predicate {
+		return func(t Task) bool { return len(idx.children[t.ID]) == 0 }
+	})
+	return f
+}
+
+// OnlyRoots filters top-level tasks. As in TaskTree, tasks whose parent is
+// not in the list count as roots.
+func (f *Filter) OnlyRoots() *Filter {
+	f.hierarchy = append(f.hierarchy, func(idx *taskIndex) Predicate {
+		return func(t Task) bool { return idx.parent(t.ID) == 0 }
+	})
+	return f
+}
+
+// MaxDepth filters tasks with at most n ancestors in the list. Root tasks
+// have depth 0, as in TaskNode.Depth.
+func (f *Filter) MaxDepth(n int) *Filter {
+	f.hierarchy = append(f.hierarchy, func(idx *taskIndex) Predicate {
+		return func(t Task) bool { return len(idx.ancestors(t.ID)) <= n }
+	})
+	return f
+}
+
+// IncludeAncestors adds the parent chains of matching tasks to the result,
+// even if the parents do not match, so that the result still forms an
+// outline. The added tasks keep their position in the original list.
+func (f *Filter) IncludeAncestors() *Filter {
+	f.includeAncestors = true
+	return f
+}
+
+// taskIndex holds the parent/child relations of a task list.
+type taskIndex struct {
+	tasks    map[int]bool
+	parents  map[int]int
+	children map[int][]int
+}
+
+// newTaskIndex indexes the hierarchy of tasks from ParentID and ChildIDs.
+func newTaskIndex(tasks []Task) *taskIndex {
+	idx := &taskIndex{
+		tasks:    make(map[int]bool, len(tasks)),
+		parents:  make(map[int]int, len(tasks)),
+		children: make(map[int][]int),
+	}
+	for _, t := range tasks {
+		idx.tasks[t.ID] = true
+	}
+
+	linked := make(map[[2]int]bool)
+	link := func(parentID, childID int) {
+		if parentID == 0 || parentID == childID || linked[[2]int{parentID, childID}] {
+			return
+		}
+		linked[[2]int{parentID, childID}] = true
+		idx.children[parentID] = append(idx.children[parentID], childID)
+		if _, ok := idx.parents[childID]; !ok {
+			idx.parents[childID] = parentID
+		}
+	}
+	for _, t := range tasks {
+		link(t.ParentID, t.ID)
+	}
+	for _, t := range tasks {
+		for _, childID := range t.ChildIDs {
+			link(t.ID, childID)
+		}
+	}
+	return idx
+}
+
+// parent returns the ID of the task's parent, or 0 if the parent is not in
+// the list.
+func (idx *taskIndex) parent(taskID int) int {
+	if parentID := idx.parents[taskID]; idx.tasks[parentID] {
+		return parentID
+	}
+	return 0
+}
+
+// ancestors returns the IDs of the task's ancestors in the list, starting
+// with its parent. Parent chains that loop back are cut off.
+func (idx *taskIndex) ancestors(taskID int) []int {
+	var result []int
+	seen := map[int]bool{taskID: true}
+	for id := idx.parent(taskID); id != 0 && !seen[id]; id = idx.parent(id) {
+		seen[id] = true
+		result = append(result, id)
+	}
+	return result
+}
+
+// descendants returns the set of IDs of the task's descendants in the list.
+func (idx *taskIndex) descendants(taskID int) map[int]bool {
+	result := make(map[int]bool)
+	stack := append([]int(nil), idx.children[taskID]...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == taskID || result[id] {
+			continue
+		}
+		result[id] = true
+		stack = append(stack, idx.children[id]...)
+	}
+	return result
+}
+
+// withAncestors returns the tasks of the list that are in result or are an
+// ancestor of a task in result, in list order.
+func (idx *taskIndex) withAncestors(tasks, result []Task) []Task {
+	keep := make(map[int]bool, len(result))
+	for _, t := range result {
+		keep[t.ID] = true
+		for _, id := range idx.ancestors(t.ID) {
+			keep[id] = true
+		}
+	}
+	expanded := make([]Task, 0, len(keep))
+	for _, t := range tasks {
+		if keep[t.ID] {
+			expanded = append(expanded, t)
+			delete(keep, t.ID)
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,91 @@
+package checkvist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func hierarchyTestTasks() []Task {
+	return []Task{
+		{ID: 1, Content: "Project", Position: 1},
+		{ID: 2, Content: "Phase 1", ParentID: 1, Position: 1},
+		{ID: 3, Content: "Phase 2", ParentID: 1, Position: 2, TagsAsText: "urgent"},
+		{ID: 4, Content: "Step A", ParentID: 2, Position: 1, TagsAsText: "urgent"},
+		{ID: 5, Content: "Step B", ParentID: 2, Position: 2, Status: StatusClosed},
+		{ID: 6, Content: "Errands", Position: 2, ChildIDs: []int{7}},
+		{ID: 7, Content: "Milk"},
+		{ID: 8, Content: "Orphan", ParentID: 99},
+		{ID: 9, Content: "Unloaded", ChildIDs: []int{100}},
+	}
+}
+
+func TestFilter_Hierarchy(t *testing.T) {
+	tasks := hierarchyTestTasks()
+
+	tests := []struct {
+		name     string
+		filter   func(*Filter) *Filter
+		expected []int
+	}{
+		{"descendants", func(f *Filter) *Filter { return f.WithDescendantsOf(1) }, []int{2, 3, 4, 5}},
+		{"descendants via child IDs", func(f *Filter) *Filter { return f.WithDescendantsOf(6) }, []int{7}},
+		{"descendants of leaf", func(f *Filter) *Filter { return f.WithDescendantsOf(4) }, []int{}},
+		{"ancestors", func(f *Filter) *Filter { return f.WithAncestorsOf(4) }, []int{1, 2}},
+		{"ancestors via child IDs", func(f *Filter) *Filter { return f.WithAncestorsOf(7) }, []int{6}},
+		{"leaves", func(f *Filter) *Filter { return f.OnlyLeaves() }, []int{3, 4, 5, 7, 8}},
+		{"roots", func(f *Filter) *Filter { return f.OnlyRoots() }, []int{1, 6, 8, 9}},
+		{"max depth 0", func(f *Filter) *Filter { return f.MaxDepth(0) }, []int{1, 6, 8, 9}},
+		{"max depth 1", func(f *Filter) *Filter { return f.MaxDepth(1) }, []int{1, 2, 3, 6, 7, 8, 9}},
+		{"open leaves under task", func(f *Filter) *Filter {
+			return f.WithDescendantsOf(1).OnlyLeaves().WithStatus(StatusOpen)
+		}, []int{3, 4}},
+		{"include ancestors", func(f *Filter) *Filter { return f.WithTag("urgent").IncludeAncestors() }, []int{1, 2, 3, 4}},
+		{"include ancestors of child IDs", func(f *Filter) *Filter { return f.WithSearch("milk").IncludeAncestors() }, []int{6, 7}},
+		{"include ancestors without conditions", func(f *Filter) *Filter { return f.IncludeAncestors() }, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.filter(NewFilter(tasks)).Apply()
+			if got := taskIDs(result); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFilter_Hierarchy_Cycle(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, ParentID: 2},
+		{ID: 2, ParentID: 1},
+		{ID: 3, ParentID: 1, TagsAsText: "x"},
+	}
+
+	if got := taskIDs(NewFilter(tasks).WithAncestorsOf(3).Apply()); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("expected ancestors [1 2], got %v", got)
+	}
+	if got := taskIDs(NewFilter(tasks).WithDescendantsOf(1).Apply()); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Errorf("expected descendants [2 3], got %v", got)
+	}
+	if got := taskIDs(NewFilter(tasks).WithTag("x").IncludeAncestors().Apply()); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+}
+
+func TestFilter_Hierarchy_ApplyTo(t *testing.T) {
+	query, err := ParseQuery("#urgent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := query.OnlyLeaves().IncludeAncestors().SortBy(Desc(SortPosition))
+
+	result := f.ApplyTo(hierarchyTestTasks())
+	if got := taskIDs(result); !reflect.DeepEqual(got, []int{3, 1, 2, 4}) {
+		t.Errorf("expected [3 1 2 4], got %v", got)
+	}
+
+	pred := NewFilter(hierarchyTestTasks()).WithDescendantsOf(2).Predicate()
+	if !pred(Task{ID: 4}) || pred(Task{ID: 3}) {
+		t.Error("expected Predicate to resolve hierarchy conditions against the filter's tasks")
+	}
+}
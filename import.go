@@ -0,0 +1,167 @@
+package checkvist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// import.go contains bulk import of indented text outlines into a checklist.
+
+// ImportMode selects how TaskService.Import creates tasks.
+type ImportMode int
+
+const (
+	// ImportAuto uses the bulk import endpoint and falls back to creating
+	// tasks one by one if the endpoint is unavailable.
+	ImportAuto ImportMode = iota
+	// ImportBulk uses only the bulk import endpoint.
+	ImportBulk
+	// ImportLocal parses the outline locally and creates tasks one by one.
+	ImportLocal
+)
+
+// ImportOptions configures the Import operation.
+type ImportOptions struct {
+	// ParentID is the task under which the outline is imported, or 0 for the root level.
+	ParentID int
+	// Position is the position of the first top-level item among its siblings.
+	// Zero appends the items at the end.
+	Position int
+	// Mode selects bulk or local import. Defaults to ImportAuto.
+	Mode ImportMode
+}
+
+// importRequest is the request body for the bulk import endpoint.
+type importRequest struct {
+	ImportContent string `json:"import_content"`
+	ParentID      int    `json:"parent_id,omitempty"`
+	Position      int    `json:"position,omitempty"`
+}
+
+// Import creates tasks from an indented text outline. Each non-empty line
+// becomes a task; lines indented deeper than the preceding line become its
+// subtasks. Tabs and spaces are both accepted for indentation.
+//
+// Example outline:
+//
+//	Release 1.2
+//	  Update changelog
+//	  Tag release
+//	    Push tag
+//
+// In bulk mode the outline is sent in a single request and the server applies
+// Checkvist's smart syntax to each line. In local mode the line content is
+// used verbatim. The created tasks are returned in outline order.
+func (s *TaskService) Import(ctx context.Context, outline string, opts ImportOptions) ([]Task, error) {
+	if strings.TrimSpace(outline) == "" {
+		return []Task{}, nil
+	}
+
+	if opts.Mode == ImportLocal {
+		return s.importLocal(ctx, outline, opts)
+	}
+
+	tasks, err := s.importBulk(ctx, outline, opts)
+	if err != nil && opts.Mode == ImportAuto && isEndpointUnavailable(err) {
+		s.client.logger.Debug("bulk import unavailable, importing tasks one by one",
+			"checklist_id", s.checklistID,
+		)
+		return s.importLocal(ctx, outline, opts)
+	}
+	return tasks, err
+}
+
+// importBulk sends the outline to the bulk import endpoint.
+func (s *TaskService) importBulk(ctx context.Context, outline string, opts ImportOptions) ([]Task, error) {
+	path := fmt.Sprintf("/checklists/%d/import.json", s.checklistID)
+	body := importRequest{
+		ImportContent: outline,
+		ParentID:      opts.ParentID,
+		Position:      opts.Position,
+	}
+
+	var tasks []Task
+	if err := s.client.doPost(ctx, path, body, &tasks); err != nil {
+		return nil, err
+	}
+
+	for i := range tasks {
+		parseTask(&tasks[i])
+	}
+	return tasks, nil
+}
+
+// importLocal parses the outline and creates its tasks one by one.
+func (s *TaskService) importLocal(ctx context.Context, outline string, opts ImportOptions) ([]Task, error) {
+	return s.CreateOutline(ctx, parseOutline(outline), opts.ParentID, opts.Position)
+}
+
+// parseOutline parses an indented text outline into a tree of nodes.
+// A line becomes a child of the nearest preceding line with a smaller indent,
+// so the indentation width per level does not need to be consistent.
+func parseOutline(outline string) []*OutlineNode {
+	type level struct {
+		indent int
+		node   *OutlineNode
+	}
+
+	var roots []*OutlineNode
+	var stack []level
+
+	for _, line := range strings.Split(outline, "\n") {
+		content := strings.TrimSpace(line)
+		if content == "" {
+			continue
+		}
+
+		indent := outlineIndent(line)
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		node := &OutlineNode{Task: NewTask(content)}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, level{indent: indent, node: node})
+	}
+
+	return roots
+}
+
+// outlineIndent returns the width of the leading whitespace of a line,
+// counting a tab as four spaces.
+func outlineIndent(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// isEndpointUnavailable reports whether err indicates that the server does not
+// provide the requested endpoint.
+func isEndpointUnavailable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+const testOutline = `Release 1.2
+  Update changelog
+  Tag release
+	Push tag
+
+Announce
+`
+
+func TestParseOutline(t *testing.T) {
+	items := parseOutline(testOutline)
+
+	if len(items) != 2 {
+		t.Fatalf("expected 2 top-level items, got %d", len(items))
+	}
+	if items[0].Task.content != "Release 1.2" || items[1].Task.content != "Announce" {
+		t.Errorf("unexpected top-level items: %q, %q", items[0].Task.content, items[1].Task.content)
+	}
+	children := items[0].Children
+	if len(children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(children))
+	}
+	if children[1].Task.content != "Tag release" {
+		t.Errorf("expected 'Tag release', got %q", children[1].Task.content)
+	}
+	if len(children[1].Children) != 1 || children[1].Children[0].Task.content != "Push tag" {
+		t.Errorf("expected tab-indented 'Push tag' under 'Tag release'")
+	}
+}
+
+// importServer simulates task creation and records created tasks. If bulk is
+// false, the bulk import endpoint responds with 404.
+func importServer(t *testing.T, bulk bool) (*httptest.Server, *[]CreateTaskRequest) {
+	t.Helper()
+	var mu sync.Mutex
+	var created []CreateTaskRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/1/import.json":
+			if !bulk {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var req importRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.ImportContent != testOutline {
+				t.Errorf("expected outline to be sent verbatim, got %q", req.ImportContent)
+			}
+			if req.ParentID != 10 || req.Position != 2 {
+				t.Errorf("expected parent 10 position 2, got %d/%d", req.ParentID, req.Position)
+			}
+			json.NewEncoder(w).Encode([]Task{
+				{ID: 1, Content: "Release 1.2", ParentID: 10},
+				{ID: 2, Content: "Update changelog", ParentID: 1},
+			})
+		case "/checklists/1/tasks.json":
+			var req createTaskWrapper
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			mu.Lock()
+			created = append(created, req.Task)
+			id := 100 + len(created)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(Task{
+				ID:       id,
+				Content:  req.Task.Content,
+				ParentID: req.Task.ParentID,
+				Position: req.Task.Position,
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	return server, &created
+}
+
+func TestTasks_Import_Bulk(t *testing.T) {
+	server, created := importServer(t, true)
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	tasks, err := client.Tasks(1).Import(context.Background(), testOutline, ImportOptions{
+		ParentID: 10,
+		Position: 2,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("expected 2 tasks, got %d", len(tasks))
+	}
+	if len(*created) != 0 {
+		t.Errorf("expected no individual create requests, got %d", len(*created))
+	}
+}
+
+func TestTasks_Import_Local(t *testing.T) {
+	tests := []struct {
+		name string
+		mode ImportMode
+	}{
+		{"explicit local mode", ImportLocal},
+		{"auto fallback", ImportAuto},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, created := importServer(t, false)
+			defer server.Close()
+
+			client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+			tasks, err := client.Tasks(1).Import(context.Background(), testOutline, ImportOptions{
+				ParentID: 10,
+				Position: 2,
+				Mode:     tc.mode,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(tasks) != 5 {
+				t.Fatalf("expected 5 tasks, got %d", len(tasks))
+			}
+
+			expected := []struct {
+				content  string
+				parentID int
+				position int
+			}{
+				{"Release 1.2", 10, 2},
+				{"Update changelog", 101, 0},
+				{"Tag release", 101, 0},
+				{"Push tag", 103, 0},
+				{"Announce", 10, 3},
+			}
+			for i, want := range expected {
+				got := (*created)[i]
+				if got.Content != want.content || got.ParentID != want.parentID || got.Position != want.position {
+					t.Errorf("task %d: expected %+v, got %+v", i, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTasks_Import_BulkUnavailable(t *testing.T) {
+	server, _ := importServer(t, false)
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	_, err := client.Tasks(1).Import(context.Background(), testOutline, ImportOptions{Mode: ImportBulk})
+
+	if err == nil {
+		t.Fatal("expected error when bulk endpoint is unavailable")
+	}
+}
@@ -0,0 +1,284 @@
+package checkvist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// markdown.go contains GitHub-flavored Markdown (task list) export and import
+// of checklists.
+//
+// Each task is written as a task list item, nested two spaces per level:
+//
+//	- [ ] Prepare release #work ^2026-02-01 !1
+//	  > A note on the task
+//	  - [x] Write changelog
+//	  - [x] ~~Dropped step~~
+//
+// Closed tasks are checked, invalidated tasks are checked and struck through.
+// Tags, due dates and priorities are appended using Checkvist's smart syntax
+// (#tag, ^due, !priority); due dates containing whitespace are quoted, as in
+// ^"next week". Trailing words of the content that look like these tokens are
+// escaped with a backslash, as in "Fix bug \#42", so that they are not read
+// back as tags, due dates or priorities. Notes are indented blockquotes;
+// consecutive notes are separated by a blank line. Tags must not contain
+// whitespace to round-trip.
+
+// markdownIndent is the indentation per nesting level.
+const markdownIndent = "  "
+
+var (
+	markdownItemRe  = regexp.MustCompile(`^(\s*)[-*+] (?:\[([ xX])\] )?(.*)$`)
+	markdownQuoteRe = regexp.MustCompile(`^(\s*)> ?(.*)$`)
+	markdownTitleRe = regexp.MustCompile(`^# (.*)$`)
+)
+
+// ExportMarkdown writes the checklist and its tasks as a Markdown task list
+// headed by the checklist name. The notes map is keyed by task ID; tasks
+// without an entry use Task.Notes. It may be nil.
+func ExportMarkdown(w io.Writer, checklist Checklist, tasks []Task, notes map[int][]Note) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s\n\n", checklist.Name)
+	for _, node := range outlineFromTree(NewTaskTree(tasks).Roots(), notes) {
+		writeMarkdownNode(bw, node, 0)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing Markdown: %w", err)
+	}
+	return nil
+}
+
+// writeMarkdownNode writes a node, its notes and its children at the given depth.
+func writeMarkdownNode(w *bufio.Writer, node *OutlineNode, depth int) {
+	b := node.Task
+	indent := strings.Repeat(markdownIndent, depth)
+
+	box := " "
+	content := escapeMarkdownContent(b.content)
+	switch node.Status {
+	case StatusClosed:
+		box = "x"
+	case StatusInvalidated:
+		box = "x"
+		content = "~~" + content + "~~"
+	}
+
+	var suffix []string
+	for _, tag := range b.tags {
+		suffix = append(suffix, "#"+tag)
+	}
+	if b.due != "" {
+		if strings.ContainsAny(b.due, " \t") {
+			suffix = append(suffix, `^"`+b.due+`"`)
+		} else {
+			suffix = append(suffix, "^"+b.due)
+		}
+	}
+	if b.priority != 0 {
+		suffix = append(suffix, "!"+strconv.Itoa(b.priority))
+	}
+	if len(suffix) > 0 {
+		content += " " + strings.Join(suffix, " ")
+	}
+
+	fmt.Fprintf(w, "%s- [%s] %s\n", indent, box, content)
+
+	noteIndent := indent + markdownIndent
+	for i, note := range node.Notes {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		for _, line := range strings.Split(note, "\n") {
+			if line == "" {
+				fmt.Fprintf(w, "%s>\n", noteIndent)
+			} else {
+				fmt.Fprintf(w, "%s> %s\n", noteIndent, line)
+			}
+		}
+	}
+
+	for _, child := range node.Children {
+		writeMarkdownNode(w, child, depth+1)
+	}
+}
+
+// ParseMarkdown parses a Markdown task list into an Outline that can be
+// created with TaskService.CreateOutline. The first level-one heading becomes
+// the outline title. List items without a checkbox are imported as open
+// tasks; other lines are ignored.
+func ParseMarkdown(r io.Reader) (*Outline, error) {
+	type level struct {
+		indent int
+		node   *OutlineNode
+	}
+
+	outline := &Outline{}
+	var stack []level
+	var current *OutlineNode
+	var note []string
+	inNote := false
+
+	flushNote := func() {
+		if inNote && current != nil {
+			current.Notes = append(current.Notes, strings.Join(note, "\n"))
+		}
+		note = nil
+		inNote = false
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if m := markdownQuoteRe.FindStringSubmatch(line); m != nil && current != nil {
+			note = append(note, m[2])
+			inNote = true
+			continue
+		}
+		flushNote()
+
+		if m := markdownItemRe.FindStringSubmatch(line); m != nil {
+			indent := outlineIndent(m[1])
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+
+			node := parseMarkdownItem(m[2], m[3])
+			if len(stack) == 0 {
+				outline.Nodes = append(outline.Nodes, node)
+			} else {
+				parent := stack[len(stack)-1].node
+				parent.Children = append(parent.Children, node)
+			}
+			stack = append(stack, level{indent: indent, node: node})
+			current = node
+			continue
+		}
+
+		if m := markdownTitleRe.FindStringSubmatch(line); m != nil && outline.Title == "" && len(outline.Nodes) == 0 {
+			outline.Title = strings.TrimSpace(m[1])
+		}
+	}
+	flushNote()
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading Markdown: %w", err)
+	}
+	return outline, nil
+}
+
+// parseMarkdownItem builds an outline node from a list item's checkbox
+// state and text, extracting trailing #tags, ^due and !priority tokens.
+func parseMarkdownItem(box, text string) *OutlineNode {
+	var tags []string
+	var due string
+	priority := 0
+
+	content := strings.TrimSpace(text)
+	for content != "" {
+		rest, word := lastMarkdownWord(content)
+		if len(word) < 2 {
+			break
+		}
+		if word[0] == '#' {
+			tags = append([]string{word[1:]}, tags...)
+		} else if len(word) > 3 && strings.HasPrefix(word, `^"`) && strings.HasSuffix(word, `"`) && due == "" {
+			due = word[2 : len(word)-1]
+		} else if word[0] == '^' && due == "" {
+			due = word[1:]
+		} else if n, err := strconv.Atoi(word[1:]); word[0] == '!' && err == nil && priority == 0 {
+			priority = n
+		} else {
+			break
+		}
+		content = rest
+	}
+
+	status := StatusOpen
+	if box == "x" || box == "X" {
+		status = StatusClosed
+		if len(content) > 4 && strings.HasPrefix(content, "~~") && strings.HasSuffix(content, "~~") {
+			status = StatusInvalidated
+			content = content[2 : len(content)-2]
+		}
+	}
+	content = unescapeMarkdownContent(content)
+
+	builder := NewTask(content).WithPriority(priority)
+	if due != "" {
+		builder.WithDueDate(DueString(due))
+	}
+	if len(tags) > 0 {
+		builder.WithTags(tags...)
+	}
+	return &OutlineNode{Task: builder, Status: status}
+}
+
+// lastMarkdownWord splits off the last word of an item's text. A quoted due
+// date such as ^"next week", optionally escaped, counts as one word.
+func lastMarkdownWord(content string) (rest, word string) {
+	if strings.HasSuffix(content, `"`) {
+		if j := strings.LastIndex(content, `^"`); j >= 0 && j+2 < len(content)-1 {
+			k := j
+			for k > 0 && content[k-1] == '\\' {
+				k--
+			}
+			if k == 0 || content[k-1] == ' ' || content[k-1] == '\t' {
+				return strings.TrimRight(content[:k], " \t"), content[k:]
+			}
+		}
+	}
+	i := strings.LastIndexAny(content, " \t")
+	if i < 0 {
+		return "", content
+	}
+	return strings.TrimRight(content[:i], " \t"), content[i+1:]
+}
+
+// isMarkdownToken reports whether a word, after any escaping backslashes,
+// starts like a #tag, ^due or !priority token.
+func isMarkdownToken(word string) bool {
+	word = strings.TrimLeft(word, `\`)
+	return len(word) >= 2 && strings.ContainsRune("#^!", rune(word[0]))
+}
+
+// escapeMarkdownContent escapes the trailing words of task content that
+// parseMarkdownItem would read as tokens by prefixing them with a backslash.
+func escapeMarkdownContent(content string) string {
+	var escaped []string
+	rest := content
+	for rest != "" {
+		before, word := lastMarkdownWord(rest)
+		if !isMarkdownToken(word) {
+			break
+		}
+		escaped = append(escaped, rest[len(before):len(rest)-len(word)]+`\`+word)
+		rest = before
+	}
+	for i := len(escaped) - 1; i >= 0; i-- {
+		rest += escaped[i]
+	}
+	return rest
+}
+
+// unescapeMarkdownContent reverses escapeMarkdownContent.
+func unescapeMarkdownContent(content string) string {
+	var unescaped []string
+	rest := content
+	for rest != "" {
+		before, word := lastMarkdownWord(rest)
+		if !strings.HasPrefix(word, `\`) || !isMarkdownToken(word) {
+			break
+		}
+		unescaped = append(unescaped, rest[len(before):len(rest)-len(word)]+word[1:])
+		rest = before
+	}
+	for i := len(unescaped) - 1; i >= 0; i-- {
+		rest += unescaped[i]
+	}
+	return rest
+}
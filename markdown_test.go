@@ -0,0 +1,139 @@
+package checkvist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportMarkdown(t *testing.T) {
+	checklist, tasks, notes := opmlFixture()
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(&buf, checklist, tasks, notes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `# Release & Deploy
+
+- [ ] Prepare <release> #urgent #work ^2026-02-01 !1
+  > First note
+
+  > Second
+  > line
+  - [x] Write changelog
+  - [x] ~~Old step~~
+- [ ] Announce
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected Markdown:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestMarkdown_RoundTrip(t *testing.T) {
+	checklist, tasks, notes := opmlFixture()
+
+	var buf bytes.Buffer
+	if err := ExportMarkdown(&buf, checklist, tasks, notes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	outline, err := ParseMarkdown(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if outline.Title != checklist.Name {
+		t.Errorf("expected title %q, got %q", checklist.Name, outline.Title)
+	}
+	if len(outline.Nodes) != 2 {
+		t.Fatalf("expected 2 top-level nodes, got %d", len(outline.Nodes))
+	}
+
+	root := outline.Nodes[0]
+	req := root.Task.build()
+	if req.Content != "Prepare <release>" || req.Priority != 1 || req.Due != "2026-02-01" || req.Tags != "urgent, work" {
+		t.Errorf("unexpected root attributes: %+v", req)
+	}
+	if len(root.Notes) != 2 || root.Notes[0] != "First note" || root.Notes[1] != "Second\nline" {
+		t.Errorf("expected notes to round-trip, got %q", root.Notes)
+	}
+	if len(root.Children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(root.Children))
+	}
+	if root.Children[0].Status != StatusClosed || root.Children[1].Status != StatusInvalidated {
+		t.Errorf("expected statuses to round-trip, got %v and %v", root.Children[0].Status, root.Children[1].Status)
+	}
+	if root.Children[1].Task.content != "Old step" {
+		t.Errorf("expected strikethrough to be removed, got %q", root.Children[1].Task.content)
+	}
+}
+
+func TestMarkdown_RoundTripLiteralTokens(t *testing.T) {
+	tests := []struct {
+		content string
+		status  TaskStatus
+		due     string
+	}{
+		{"Fix bug #42", StatusOpen, ""},
+		{"Ship it !1 ^friday", StatusOpen, "2026-11-01"},
+		{`Escaped \#tag`, StatusOpen, ""},
+		{"#42", StatusInvalidated, ""},
+		{`Say ^"hello world"`, StatusOpen, ""},
+		{"Plan trip", StatusOpen, "next week"},
+		{"Wow !!", StatusClosed, "next week"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.content, func(t *testing.T) {
+			tasks := []Task{{ID: 1, Content: tc.content, Status: tc.status, DueDateRaw: tc.due, TagsAsText: "work"}}
+
+			var buf bytes.Buffer
+			if err := ExportMarkdown(&buf, Checklist{Name: "List"}, tasks, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			outline, err := ParseMarkdown(&buf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(outline.Nodes) != 1 {
+				t.Fatalf("expected 1 node, got %d", len(outline.Nodes))
+			}
+
+			node := outline.Nodes[0]
+			req := node.Task.build()
+			if req.Content != tc.content || req.Due != tc.due || req.Tags != "work" || node.Status != tc.status {
+				t.Errorf("expected %q due %q tags work status %v, got %+v status %v", tc.content, tc.due, tc.status, req, node.Status)
+			}
+		})
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	input := `Some intro text.
+
+* [X] Done item
+* Plain item with #hashtag in the middle
+    - [ ] Deeply indented child !2
+`
+
+	outline, err := ParseMarkdown(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if outline.Title != "" {
+		t.Errorf("expected no title, got %q", outline.Title)
+	}
+	if len(outline.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(outline.Nodes))
+	}
+	if outline.Nodes[0].Status != StatusClosed {
+		t.Errorf("expected uppercase X to mark closed")
+	}
+	plain := outline.Nodes[1]
+	if plain.Task.content != "Plain item with #hashtag in the middle" || len(plain.Task.tags) != 0 {
+		t.Errorf("expected inline hashtag to stay in content, got %q %v", plain.Task.content, plain.Task.tags)
+	}
+	if len(plain.Children) != 1 || plain.Children[0].Task.priority != 2 {
+		t.Errorf("expected child with priority 2, got %+v", plain.Children)
+	}
+}
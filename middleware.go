@@ -0,0 +1,103 @@
+package checkvist
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"regexp"
+)
+
+// middleware.go contains the request middleware chain and the built-in
+// middlewares.
+
+// RoundTripFunc sends a single HTTP request and returns its response.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to inspect or modify requests and
+// responses. Middlewares apply to every request the client sends, including
+// authentication and token refresh requests, and run once per attempt.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// send sends the request through the middleware chain. The first registered
+// middleware is the outermost one.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	rt := RoundTripFunc(c.httpClient.Do)
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+	return rt(req)
+}
+
+// UserAgentMiddleware sets the User-Agent header of every request.
+func UserAgentMiddleware(userAgent string) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("User-Agent", userAgent)
+			return next(req)
+		}
+	}
+}
+
+// RequestIDMiddleware sets the X-Request-Id header of every request that does
+// not already have one. IDs are obtained from generate, or are random 16-byte
+// hex strings if generate is nil.
+func RequestIDMiddleware(generate func() string) Middleware {
+	if generate == nil {
+		generate = randomRequestID
+	}
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Request-Id") == "" {
+				req.Header.Set("X-Request-Id", generate())
+			}
+			return next(req)
+		}
+	}
+}
+
+// randomRequestID returns a random 16-byte hex string.
+func randomRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// DebugDumpMiddleware logs the full wire format of every request and response
+// at debug level. Credentials are redacted: the X-Client-Token header, the
+// remote_key, old_token and totp form fields, and tokens in response bodies.
+func DebugDumpMiddleware(logger *slog.Logger) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if dump, err := httputil.DumpRequestOut(req, true); err == nil {
+				logger.Debug("http request", "dump", redactDump(dump))
+			}
+			resp, err := next(req)
+			if err != nil {
+				return nil, err
+			}
+			if dump, err := httputil.DumpResponse(resp, true); err == nil {
+				logger.Debug("http response", "dump", redactDump(dump))
+			}
+			return resp, nil
+		}
+	}
+}
+
+var (
+	redactHeader = regexp.MustCompile(`(?im)^(X-Client-Token:[ \t]*)[^\r\n]*`)
+	redactForm   = regexp.MustCompile(`\b(remote_key|old_token|totp)=[^&\s]*`)
+	redactJSON   = regexp.MustCompile(`("token"\s*:\s*)"[^"]*"`)
+)
+
+// redactDump removes credentials from a request or response dump.
+func redactDump(dump []byte) string {
+	dump = redactHeader.ReplaceAll(dump, []byte("${1}REDACTED"))
+	dump = redactForm.ReplaceAll(dump, []byte("${1}=REDACTED"))
+	dump = redactJSON.ReplaceAll(dump, []byte(`${1}"REDACTED"`))
+	return string(dump)
+}
@@ -0,0 +1,134 @@
+package checkvist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClient_Middleware(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.URL.Path] = r.Header.Get("User-Agent") + "|" + r.Header.Get("X-Trace")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/auth/curr_user.json":
+			json.NewEncoder(w).Encode(User{ID: 1})
+		case "/checklists.json":
+			w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				req.Header.Add("X-Trace", name)
+				return next(req)
+			}
+		}
+	}
+
+	client := NewClient("user@example.com", "api-key",
+		WithBaseURL(server.URL),
+		WithMiddleware(UserAgentMiddleware("test-agent/1.0"), trace("outer")),
+		WithMiddleware(trace("inner")),
+	)
+	ctx := context.Background()
+	if _, err := client.CurrentUser(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Checklists().List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{"/auth/login.json", "/auth/curr_user.json", "/checklists.json"} {
+		if seen[path] != "test-agent/1.0|outer" {
+			t.Errorf("%s: expected middlewares to apply, got %q", path, seen[path])
+		}
+	}
+	if len(order) != 6 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("expected outer before inner on each request, got %v", order)
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var got []string
+	next := func(req *http.Request) (*http.Response, error) {
+		got = append(got, req.Header.Get("X-Request-Id"))
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}
+
+	rt := RequestIDMiddleware(func() string { return "fixed" })(next)
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	rt(req)
+	req, _ = http.NewRequest(http.MethodGet, "https://example.com", nil)
+	req.Header.Set("X-Request-Id", "existing")
+	rt(req)
+
+	random := RequestIDMiddleware(nil)(next)
+	req, _ = http.NewRequest(http.MethodGet, "https://example.com", nil)
+	random(req)
+
+	if got[0] != "fixed" || got[1] != "existing" || len(got[2]) != 32 {
+		t.Errorf("unexpected request IDs: %v", got)
+	}
+}
+
+func TestDebugDumpMiddleware_Redacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "secret-token"})
+		case "/checklists.json":
+			w.Write([]byte(`[{"id":1,"name":"Visible"}]`))
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient("user@example.com", "secret-key",
+		WithBaseURL(server.URL),
+		WithMiddleware(DebugDumpMiddleware(logger)),
+	)
+	checklists, err := client.Checklists().List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checklists) != 1 {
+		t.Fatalf("expected the response body to survive dumping, got %v", checklists)
+	}
+
+	out := buf.String()
+	for _, secret := range []string{"secret-key", "secret-token"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted from:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "remote_key=REDACTED") || !strings.Contains(out, "X-Client-Token: REDACTED") {
+		t.Errorf("expected redaction markers in:\n%s", out)
+	}
+	if !strings.Contains(out, "Visible") {
+		t.Errorf("expected response body in dump")
+	}
+}
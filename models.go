@@ -2,6 +2,7 @@ package checkvist
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -36,8 +37,68 @@ func (s TaskStatus) String() string {
 }
 
 // Tags represents a set of tags as a map for efficient lookup.
+// Tag names are stored lowercased and trimmed of surrounding whitespace.
 type Tags map[string]bool
 
+// ParseTags parses a comma-separated tag string, as returned in TagsAsText,
+// into a Tags set. Empty entries are ignored.
+func ParseTags(s string) Tags {
+	tags := Tags{}
+	for _, part := range strings.Split(s, ",") {
+		tags.Add(part)
+	}
+	return tags
+}
+
+// normalizeTag lowercases and trims a tag name.
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// Add adds the given tags to the set. Empty tag names are ignored.
+// A nil set is allocated on first use, so Add works on the zero value,
+// e.g. the Tags of a Task built by hand.
+func (t *Tags) Add(tags ...string) {
+	for _, tag := range tags {
+		if tag = normalizeTag(tag); tag != "" {
+			if *t == nil {
+				*t = Tags{}
+			}
+			(*t)[tag] = true
+		}
+	}
+}
+
+// Remove removes the given tags from the set.
+func (t Tags) Remove(tags ...string) {
+	for _, tag := range tags {
+		delete(t, normalizeTag(tag))
+	}
+}
+
+// Has reports whether the set contains the given tag (case-insensitive).
+func (t Tags) Has(tag string) bool {
+	return t[normalizeTag(tag)]
+}
+
+// Sorted returns the tag names in alphabetical order.
+func (t Tags) Sorted() []string {
+	result := make([]string, 0, len(t))
+	for tag, ok := range t {
+		if ok {
+			result = append(result, tag)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// String returns the tags as a comma-separated string in the format
+// accepted by the API, e.g. "urgent, work".
+func (t Tags) String() string {
+	return strings.Join(t.Sorted(), ", ")
+}
+
 // APITime wraps time.Time with custom JSON unmarshaling for Checkvist API format.
 // The Checkvist API returns timestamps in format "2006/01/02 15:04:05 +0000"
 // instead of the standard RFC3339 format that Go expects.
@@ -164,3 +164,75 @@ func TestNewAPITime(t *testing.T) {
 		t.Errorf("NewAPITime() did not preserve time: got %v, want %v", apiTime.Time, now)
 	}
 }
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", []string{}},
+		{"single", "work", []string{"work"}},
+		{"comma separated", "work, urgent", []string{"urgent", "work"}},
+		{"normalizes case and whitespace", "  Work ,URGENT,, ", []string{"urgent", "work"}},
+		{"deduplicates", "work, Work", []string{"work"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ParseTags(tc.input).Sorted()
+			if len(got) != len(tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, got)
+			}
+			for i := range got {
+				if got[i] != tc.expected[i] {
+					t.Errorf("expected %v, got %v", tc.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTags_Mutation(t *testing.T) {
+	tags := ParseTags("work")
+
+	tags.Add("Urgent", " home ", "")
+	if !tags.Has("urgent") || !tags.Has("HOME") {
+		t.Errorf("expected added tags to be present, got %v", tags)
+	}
+	if len(tags) != 3 {
+		t.Errorf("expected 3 tags, got %d", len(tags))
+	}
+
+	tags.Remove("WORK", "missing")
+	if tags.Has("work") {
+		t.Error("expected work to be removed")
+	}
+
+	if got := tags.String(); got != "home, urgent" {
+		t.Errorf("expected 'home, urgent', got %q", got)
+	}
+}
+
+func TestTags_AddToZeroValue(t *testing.T) {
+	var task Task
+	task.Tags.Add("Urgent")
+	if !task.Tags.Has("urgent") || task.Tags.String() != "urgent" {
+		t.Errorf("expected tag to be added to zero Tags, got %v", task.Tags)
+	}
+
+	var empty Tags
+	empty.Add("", " ")
+	if empty != nil {
+		t.Errorf("expected empty tags not to allocate, got %v", empty)
+	}
+}
+
+func TestTags_RoundTrip(t *testing.T) {
+	req := NewTask("Task").WithTags("urgent", "work").build()
+
+	tags := ParseTags(req.Tags)
+	if tags.String() != req.Tags {
+		t.Errorf("expected round-trip %q, got %q", req.Tags, tags.String())
+	}
+}
@@ -0,0 +1,212 @@
+package checkvist
+
+import (
+	"context"
+	"fmt"
+)
+
+// move.go contains task relocation within and across checklists, including
+// the client-side copy used when the server cannot move a task directly.
+
+// MoveTarget describes where TaskService.Move places a task.
+type MoveTarget struct {
+	// ChecklistID is the destination checklist. Zero keeps the task in its
+	// current checklist.
+	ChecklistID int
+	// ParentID is the new parent task in the destination checklist, or 0 for the root level.
+	ParentID int
+	// Position is the position among the new siblings. Zero appends the task at the end.
+	Position int
+}
+
+// moveRequest is the request body for the move endpoint.
+type moveRequest struct {
+	MoveTo   int `json:"move_to"`
+	ParentID int `json:"parent_id,omitempty"`
+	Position int `json:"position,omitempty"`
+}
+
+// Move relocates a task together with its subtasks.
+//
+// Within the same checklist the task is re-parented via Update. For moves to
+// another checklist the server's move endpoint is used; if it is unavailable,
+// the subtree is copied into the destination checklist (preserving content,
+// status, notes, tags, due dates and priorities) and the original is deleted.
+// The copy receives new task IDs.
+//
+// If copying fails part-way, the original is kept and the tasks copied so far
+// remain in the destination checklist; the caller can find and delete them
+// there.
+//
+// The returned task is the moved (or copied) root task.
+func (s *TaskService) Move(ctx context.Context, taskID int, target MoveTarget) (*Task, error) {
+	if target.ChecklistID == 0 || target.ChecklistID == s.checklistID {
+		parentID := target.ParentID
+		req := UpdateTaskRequest{ParentID: &parentID}
+		if target.Position > 0 {
+			req.Position = &target.Position
+		}
+		return s.Update(ctx, taskID, req)
+	}
+
+	path := fmt.Sprintf("/checklists/%d/tasks/%d/move.json", s.checklistID, taskID)
+	body := moveRequest{
+		MoveTo:   target.ChecklistID,
+		ParentID: target.ParentID,
+		Position: target.Position,
+	}
+
+	var tasks []Task
+	err := s.client.doPost(ctx, path, body, &tasks)
+	if err == nil {
+		if len(tasks) == 0 {
+			return nil, fmt.Errorf("move task: unexpected empty response")
+		}
+		parseTask(&tasks[0])
+		return &tasks[0], nil
+	}
+	if !isEndpointUnavailable(err) {
+		return nil, err
+	}
+
+	s.client.logger.Debug("move endpoint unavailable, copying task",
+		"task_id", taskID,
+		"from", s.checklistID,
+		"to", target.ChecklistID,
+	)
+	return s.moveByCopy(ctx, taskID, target)
+}
+
+// moveByCopy copies the subtree rooted at taskID into the target checklist
+// and deletes the original once the copy is complete.
+func (s *TaskService) moveByCopy(ctx context.Context, taskID int, target MoveTarget) (*Task, error) {
+	tree, err := s.Tree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	node := tree.Node(taskID)
+	if node == nil {
+		return nil, fmt.Errorf("move task %d: %w", taskID, ErrNotFound)
+	}
+
+	dst := s.client.Tasks(target.ChecklistID)
+	copied, err := dst.copySubtree(ctx, node, target.ParentID, target.Position, copyOptions{
+		notes: s.sourceNotes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("copying task %d: %w", taskID, err)
+	}
+
+	if err := s.Delete(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("deleting original task %d after copy: %w", taskID, err)
+	}
+	return copied, nil
+}
+
+// sourceNotes returns the notes of a task in the service's checklist,
+// using the embedded notes when the API already returned them.
+func (s *TaskService) sourceNotes(ctx context.Context, task Task) ([]Note, error) {
+	if len(task.Notes) > 0 || task.CommentsCount == 0 {
+		return task.Notes, nil
+	}
+	return s.client.Notes(s.checklistID, task.ID).List(ctx)
+}
+
+// copyOptions controls how copySubtree recreates tasks.
+type copyOptions struct {
+	// notes returns the notes to recreate for a source task. Nil skips notes.
+	notes func(ctx context.Context, task Task) ([]Note, error)
+	// transform may modify the builder and note texts before a task is created.
+	transform func(task Task, builder *TaskBuilder, notes []string) ([]string, error)
+	// created is called after a task and its notes have been recreated.
+	created func(src Task, dst *Task)
+	// keepOpen skips restoring the closed or invalidated status of source tasks.
+	keepOpen bool
+}
+
+// builderFromTask returns a TaskBuilder that recreates the task's content,
+// due date, priority and tags.
+func builderFromTask(task Task) *TaskBuilder {
+	builder := NewTask(task.Content).WithPriority(task.Priority)
+	if task.DueDate != nil {
+		builder.WithDueDate(DueAt(*task.DueDate))
+	} else if task.DueDateRaw != "" {
+		builder.WithDueDate(DueString(task.DueDateRaw))
+	}
+	tags := task.Tags
+	if tags == nil {
+		tags = ParseTags(task.TagsAsText)
+	}
+	if len(tags) > 0 {
+		builder.WithTags(tags.Sorted()...)
+	}
+	return builder
+}
+
+// copySubtree recreates the node and its descendants in the service's
+// checklist under parentID at position (0 appends). Notes and status are
+// restored after each task is created and before its children are, so that
+// the status cascade of closing a parent does not change the children. It
+// returns the new root task.
+func (s *TaskService) copySubtree(ctx context.Context, node *TaskNode, parentID, position int, opts copyOptions) (*Task, error) {
+	src := node.Task
+
+	var notes []Note
+	if opts.notes != nil {
+		var err error
+		if notes, err = opts.notes(ctx, src); err != nil {
+			return nil, fmt.Errorf("listing notes of task %d: %w", src.ID, err)
+		}
+	}
+	comments := make([]string, len(notes))
+	for i, note := range notes {
+		comments[i] = note.Comment
+	}
+
+	builder := builderFromTask(src).WithParent(parentID).WithPosition(position)
+	if opts.transform != nil {
+		var err error
+		if comments, err = opts.transform(src, builder, comments); err != nil {
+			return nil, err
+		}
+	}
+
+	created, err := s.Create(ctx, builder)
+	if err != nil {
+		return nil, fmt.Errorf("creating copy of task %d: %w", src.ID, err)
+	}
+
+	for _, comment := range comments {
+		if _, err := s.client.Notes(s.checklistID, created.ID).Create(ctx, comment); err != nil {
+			return nil, fmt.Errorf("copying note of task %d: %w", src.ID, err)
+		}
+	}
+
+	// Apply the status before creating the children: closing a task also
+	// closes its open subtasks, which would overwrite their own status.
+	status := src.Status
+	if opts.keepOpen {
+		status = StatusOpen
+	}
+	switch status {
+	case StatusClosed:
+		if created, err = s.Close(ctx, created.ID); err != nil {
+			return nil, fmt.Errorf("closing copy of task %d: %w", src.ID, err)
+		}
+	case StatusInvalidated:
+		if created, err = s.Invalidate(ctx, created.ID); err != nil {
+			return nil, fmt.Errorf("invalidating copy of task %d: %w", src.ID, err)
+		}
+	}
+
+	for _, child := range node.Children {
+		if _, err := s.copySubtree(ctx, child, created.ID, 0, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.created != nil {
+		opts.created(src, created)
+	}
+	return created, nil
+}
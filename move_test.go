@@ -0,0 +1,164 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTasks_Move_SameChecklist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/1/tasks/101.json":
+			if r.Method != http.MethodPut {
+				t.Errorf("expected PUT, got %s", r.Method)
+			}
+			var req updateTaskWrapper
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.Task.ParentID == nil || *req.Task.ParentID != 0 {
+				t.Errorf("expected explicit parent_id 0, got %v", req.Task.ParentID)
+			}
+			if req.Task.Position == nil || *req.Task.Position != 3 {
+				t.Errorf("expected position 3, got %v", req.Task.Position)
+			}
+			json.NewEncoder(w).Encode(Task{ID: 101, ChecklistID: 1, Position: 3})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	task, err := client.Tasks(1).Move(context.Background(), 101, MoveTarget{Position: 3})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Position != 3 {
+		t.Errorf("expected position 3, got %d", task.Position)
+	}
+}
+
+func TestTasks_Move_CrossChecklist(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/1/tasks/101/move.json":
+			var req moveRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if req.MoveTo != 2 || req.ParentID != 50 {
+				t.Errorf("expected move to checklist 2 parent 50, got %+v", req)
+			}
+			json.NewEncoder(w).Encode([]Task{{ID: 101, ChecklistID: 2, ParentID: 50, TagsAsText: "work"}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	task, err := client.Tasks(1).Move(context.Background(), 101, MoveTarget{ChecklistID: 2, ParentID: 50})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.ChecklistID != 2 || !task.Tags.Has("work") {
+		t.Errorf("unexpected moved task: %+v", task)
+	}
+}
+
+func TestTasks_Move_CopyFallback(t *testing.T) {
+	var (
+		created []CreateTaskRequest
+		notes   = map[int][]string{}
+		closed  []int
+		deleted []int
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/1/tasks/101/move.json":
+			w.WriteHeader(http.StatusNotFound)
+		case "/checklists/1/tasks.json":
+			json.NewEncoder(w).Encode([]Task{
+				{ID: 101, Content: "Parent", Status: StatusClosed, Priority: 1,
+					TagsAsText: "work, urgent", DueDateRaw: "2026/02/01", CommentsCount: 1},
+				{ID: 102, ParentID: 101, Content: "Child", Position: 1},
+				{ID: 103, Content: "Unrelated"},
+			})
+		case "/checklists/1/tasks/101/comments.json":
+			json.NewEncoder(w).Encode([]Note{{ID: 1, TaskID: 101, Comment: "Keep me"}})
+		case "/checklists/2/tasks.json":
+			var req createTaskWrapper
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			created = append(created, req.Task)
+			json.NewEncoder(w).Encode(Task{ID: 200 + len(created), ChecklistID: 2, Content: req.Task.Content})
+		case "/checklists/2/tasks/201/comments.json":
+			var req createNoteRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			notes[201] = append(notes[201], req.Comment.Comment)
+			json.NewEncoder(w).Encode(Note{ID: 1, TaskID: 201, Comment: req.Comment.Comment})
+		case "/checklists/2/tasks/201/close.json":
+			closed = append(closed, 201)
+			json.NewEncoder(w).Encode([]Task{{ID: 201, ChecklistID: 2, Status: StatusClosed}})
+		case "/checklists/1/tasks/101.json":
+			if r.Method != http.MethodDelete {
+				t.Errorf("expected DELETE, got %s", r.Method)
+			}
+			deleted = append(deleted, 101)
+		default:
+			t.Errorf("unexpected path: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	task, err := client.Tasks(1).Move(context.Background(), 101, MoveTarget{ChecklistID: 2, ParentID: 50})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.ID != 201 || task.Status != StatusClosed {
+		t.Errorf("expected closed copy 201, got %+v", task)
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected 2 created tasks, got %d", len(created))
+	}
+	parent := created[0]
+	if parent.ParentID != 50 || parent.Priority != 1 || parent.Tags != "urgent, work" || parent.Due != "2026-02-01" {
+		t.Errorf("expected attributes to be preserved, got %+v", parent)
+	}
+	if created[1].Content != "Child" || created[1].ParentID != 201 {
+		t.Errorf("expected child under copied parent, got %+v", created[1])
+	}
+	if len(notes[201]) != 1 || notes[201][0] != "Keep me" {
+		t.Errorf("expected note to be copied, got %v", notes)
+	}
+	if len(closed) != 1 {
+		t.Errorf("expected copied parent to be closed, got %v", closed)
+	}
+	if len(deleted) != 1 {
+		t.Errorf("expected original to be deleted, got %v", deleted)
+	}
+}
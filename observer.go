@@ -0,0 +1,89 @@
+package checkvist
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// observer.go contains the Observer hooks for tracing and metrics.
+
+// RequestEvent describes a single HTTP request sent by the client.
+type RequestEvent struct {
+	// Method is the HTTP method.
+	Method string
+	// Path is the path template of the endpoint, with numeric IDs replaced by
+	// {id} and without query parameters, e.g. "/checklists/{id}/tasks.json".
+	Path string
+	// Attempt is the zero-based attempt number; retries have Attempt > 0.
+	Attempt int
+	// StatusCode is the HTTP status code of the response. It is zero in
+	// RequestStart and if no response was received.
+	StatusCode int
+	// Duration is the time until the response headers were received.
+	// It is zero in RequestStart.
+	Duration time.Duration
+	// Err is the transport error, if the request failed without a response.
+	// Error status codes are reported via StatusCode only.
+	Err error
+}
+
+// Observer receives callbacks for every HTTP request the client sends,
+// including authentication and token refresh requests and each retry
+// attempt. It is intended for tracing and metrics; see the checkvistotel and
+// checkvistprom packages for OpenTelemetry and Prometheus adapters.
+//
+// Implementations must be safe for concurrent use.
+type Observer interface {
+	// RequestStart is called before a request is sent. The returned context
+	// is used for the request and passed to RequestEnd, so it may carry a span.
+	RequestStart(ctx context.Context, event RequestEvent) context.Context
+	// RequestEnd is called after the response headers were received or the
+	// request failed.
+	RequestEnd(ctx context.Context, event RequestEvent)
+}
+
+// observe sends the request through the middleware chain, reporting it to
+// the observer if one is configured. path is the API path of the request.
+func (c *Client) observe(req *http.Request, path string, attempt int) (*http.Response, error) {
+	if c.observer == nil {
+		return c.send(req)
+	}
+
+	event := RequestEvent{
+		Method:  req.Method,
+		Path:    pathTemplate(path),
+		Attempt: attempt,
+	}
+	ctx := c.observer.RequestStart(req.Context(), event)
+	req = req.WithContext(ctx)
+
+	start := time.Now()
+	resp, err := c.send(req)
+	event.Duration = time.Since(start)
+	event.Err = err
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+	}
+	c.observer.RequestEnd(ctx, event)
+
+	return resp, err
+}
+
+// pathTemplate strips the query from an API path and replaces numeric
+// segments with {id}, keeping the cardinality of metric labels low.
+func pathTemplate(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		name, ext, _ := strings.Cut(segment, ".")
+		if name != "" && strings.Trim(name, "0123456789") == "" {
+			segments[i] = "{id}"
+			if ext != "" {
+				segments[i] += "." + ext
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
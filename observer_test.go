@@ -0,0 +1,101 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type ctxKey struct{}
+
+// recordingObserver records request events and checks that the context
+// returned by RequestStart reaches RequestEnd.
+type recordingObserver struct {
+	t      *testing.T
+	mu     sync.Mutex
+	starts []RequestEvent
+	ends   []RequestEvent
+}
+
+func (o *recordingObserver) RequestStart(ctx context.Context, event RequestEvent) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.starts = append(o.starts, event)
+	return context.WithValue(ctx, ctxKey{}, event.Path)
+}
+
+func (o *recordingObserver) RequestEnd(ctx context.Context, event RequestEvent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if ctx.Value(ctxKey{}) != event.Path {
+		o.t.Errorf("expected context from RequestStart for %s", event.Path)
+	}
+	o.ends = append(o.ends, event)
+}
+
+func TestClient_Observer(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/12/tasks/345.json":
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			json.NewEncoder(w).Encode(Task{ID: 345})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	observer := &recordingObserver{t: t}
+	client := NewClient("user@example.com", "api-key",
+		WithBaseURL(server.URL),
+		WithObserver(observer),
+		WithRetryConfig(RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+	if _, err := client.Tasks(12).Get(context.Background(), 345); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(observer.starts) != 3 || len(observer.ends) != 3 {
+		t.Fatalf("expected 3 observed requests, got %d starts and %d ends", len(observer.starts), len(observer.ends))
+	}
+	login := observer.ends[0]
+	if login.Method != http.MethodPost || login.Path != "/auth/login.json" || login.StatusCode != http.StatusOK {
+		t.Errorf("unexpected login event: %+v", login)
+	}
+	failed, retried := observer.ends[1], observer.ends[2]
+	if failed.Path != "/checklists/{id}/tasks/{id}.json" || failed.StatusCode != http.StatusBadGateway || failed.Attempt != 0 {
+		t.Errorf("unexpected failed event: %+v", failed)
+	}
+	if retried.StatusCode != http.StatusOK || retried.Attempt != 1 || retried.Duration <= 0 {
+		t.Errorf("unexpected retried event: %+v", retried)
+	}
+}
+
+func TestPathTemplate(t *testing.T) {
+	tests := map[string]string{
+		"/checklists.json":                         "/checklists.json",
+		"/checklists.json?archived=true":           "/checklists.json",
+		"/checklists/12.json":                      "/checklists/{id}.json",
+		"/checklists/12/tasks/345/comments.json":   "/checklists/{id}/tasks/{id}/comments.json",
+		"/checklists/12/tasks/345/comments/6.json": "/checklists/{id}/tasks/{id}/comments/{id}.json",
+		"/auth/login.json?version=2":               "/auth/login.json",
+	}
+	for path, expected := range tests {
+		if got := pathTemplate(path); got != expected {
+			t.Errorf("%s: expected %s, got %s", path, expected, got)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package checkvist
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// opml.go contains OPML 2.0 export and import of checklists.
+//
+// Task attributes are stored as outline attributes prefixed with an
+// underscore (_status, _tags, _due, _priority), following the convention
+// used by other outliners for non-standard attributes. Notes are written as
+// child outlines with type="note"; a _note attribute written by other tools
+// is imported as a note as well.
+
+// opmlDocument is the root element of an OPML document.
+type opmlDocument struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    opmlHead `xml:"head"`
+	Body    opmlBody `xml:"body"`
+}
+
+// opmlHead is the head element of an OPML document.
+type opmlHead struct {
+	Title        string `xml:"title"`
+	DateModified string `xml:"dateModified,omitempty"`
+}
+
+// opmlBody is the body element of an OPML document.
+type opmlBody struct {
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlOutline is a single outline element.
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Type     string        `xml:"type,attr,omitempty"`
+	Status   string        `xml:"_status,attr,omitempty"`
+	Tags     string        `xml:"_tags,attr,omitempty"`
+	Due      string        `xml:"_due,attr,omitempty"`
+	Priority int           `xml:"_priority,attr,omitempty"`
+	Note     string        `xml:"_note,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// opmlNoteType is the outline type used for notes.
+const opmlNoteType = "note"
+
+// ExportOPML writes the checklist and its tasks as an OPML 2.0 document.
+// The notes map is keyed by task ID; tasks without an entry use Task.Notes.
+// It may be nil.
+func ExportOPML(w io.Writer, checklist Checklist, tasks []Task, notes map[int][]Note) error {
+	doc := opmlDocument{
+		Version: "2.0",
+		Head:    opmlHead{Title: checklist.Name},
+	}
+	if !checklist.UpdatedAt.IsZero() {
+		doc.Head.DateModified = checklist.UpdatedAt.Format(time.RFC1123Z)
+	}
+	for _, node := range outlineFromTree(NewTaskTree(tasks).Roots(), notes) {
+		doc.Body.Outlines = append(doc.Body.Outlines, opmlFromNode(node))
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("writing OPML: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding OPML: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("writing OPML: %w", err)
+	}
+	return nil
+}
+
+// opmlFromNode converts an outline node into an OPML outline element.
+func opmlFromNode(node *OutlineNode) opmlOutline {
+	b := node.Task
+	outline := opmlOutline{
+		Text:     b.content,
+		Tags:     ParseTags(strings.Join(b.tags, ",")).String(),
+		Due:      b.due,
+		Priority: b.priority,
+	}
+	if node.Status != StatusOpen {
+		outline.Status = node.Status.String()
+	}
+	for _, note := range node.Notes {
+		outline.Outlines = append(outline.Outlines, opmlOutline{Text: note, Type: opmlNoteType})
+	}
+	for _, child := range node.Children {
+		outline.Outlines = append(outline.Outlines, opmlFromNode(child))
+	}
+	return outline
+}
+
+// ParseOPML parses an OPML document into an Outline that can be created
+// with TaskService.CreateOutline.
+func ParseOPML(r io.Reader) (*Outline, error) {
+	var doc opmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding OPML: %w", err)
+	}
+
+	outline := &Outline{Title: doc.Head.Title}
+	for _, o := range doc.Body.Outlines {
+		node, err := nodeFromOPML(o)
+		if err != nil {
+			return nil, err
+		}
+		outline.Nodes = append(outline.Nodes, node)
+	}
+	return outline, nil
+}
+
+// nodeFromOPML converts an OPML outline element into an outline node.
+func nodeFromOPML(o opmlOutline) (*OutlineNode, error) {
+	builder := NewTask(o.Text).WithPriority(o.Priority)
+	if o.Due != "" {
+		builder.WithDueDate(DueString(o.Due))
+	}
+	if o.Tags != "" {
+		builder.WithTags(ParseTags(o.Tags).Sorted()...)
+	}
+
+	status, err := parseTaskStatus(o.Status)
+	if err != nil {
+		return nil, fmt.Errorf("outline %q: %w", o.Text, err)
+	}
+
+	node := &OutlineNode{Task: builder, Status: status}
+	if o.Note != "" {
+		node.Notes = append(node.Notes, o.Note)
+	}
+	for _, child := range o.Outlines {
+		if child.Type == opmlNoteType {
+			node.Notes = append(node.Notes, child.Text)
+			continue
+		}
+		childNode, err := nodeFromOPML(child)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, childNode)
+	}
+	return node, nil
+}
+
+// parseTaskStatus parses the string form of a TaskStatus. An empty string
+// is treated as open. Numeric values are accepted as well.
+func parseTaskStatus(s string) (TaskStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "open":
+		return StatusOpen, nil
+	case "closed":
+		return StatusClosed, nil
+	case "invalidated":
+		return StatusInvalidated, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= int(StatusOpen) && n <= int(StatusInvalidated) {
+		return TaskStatus(n), nil
+	}
+	return StatusOpen, fmt.Errorf("unknown task status %q", s)
+}
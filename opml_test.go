@@ -0,0 +1,186 @@
+package checkvist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func opmlFixture() (Checklist, []Task, map[int][]Note) {
+	due := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	checklist := Checklist{ID: 1, Name: "Release & Deploy"}
+	tasks := []Task{
+		{ID: 1, Content: "Prepare <release>", Position: 1, Priority: 1,
+			TagsAsText: "work, urgent", DueDateRaw: "2026/02/01", DueDate: &due},
+		{ID: 2, ParentID: 1, Content: "Write changelog", Position: 1, Status: StatusClosed},
+		{ID: 3, ParentID: 1, Content: "Old step", Position: 2, Status: StatusInvalidated},
+		{ID: 4, Content: "Announce", Position: 2},
+	}
+	notes := map[int][]Note{
+		1: {{Comment: "First note"}, {Comment: "Second\nline"}},
+	}
+	return checklist, tasks, notes
+}
+
+func TestExportOPML(t *testing.T) {
+	checklist, tasks, notes := opmlFixture()
+
+	var buf bytes.Buffer
+	if err := ExportOPML(&buf, checklist, tasks, notes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		`<opml version="2.0">`,
+		`<title>Release &amp; Deploy</title>`,
+		`text="Prepare &lt;release&gt;"`,
+		`_tags="urgent, work"`,
+		`_due="2026-02-01"`,
+		`_priority="1"`,
+		`_status="closed"`,
+		`type="note"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %s\n%s", want, out)
+		}
+	}
+}
+
+func TestOPML_RoundTrip(t *testing.T) {
+	checklist, tasks, notes := opmlFixture()
+
+	var buf bytes.Buffer
+	if err := ExportOPML(&buf, checklist, tasks, notes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	outline, err := ParseOPML(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if outline.Title != checklist.Name {
+		t.Errorf("expected title %q, got %q", checklist.Name, outline.Title)
+	}
+	if len(outline.Nodes) != 2 {
+		t.Fatalf("expected 2 top-level nodes, got %d", len(outline.Nodes))
+	}
+
+	root := outline.Nodes[0]
+	req := root.Task.build()
+	if req.Content != "Prepare <release>" || req.Priority != 1 || req.Due != "2026-02-01" || req.Tags != "urgent, work" {
+		t.Errorf("unexpected root attributes: %+v", req)
+	}
+	if len(root.Notes) != 2 || root.Notes[1] != "Second\nline" {
+		t.Errorf("expected notes to round-trip, got %q", root.Notes)
+	}
+	if len(root.Children) != 2 {
+		t.Fatalf("expected 2 children, got %d", len(root.Children))
+	}
+	if root.Children[0].Status != StatusClosed || root.Children[1].Status != StatusInvalidated {
+		t.Errorf("expected statuses to round-trip, got %v and %v", root.Children[0].Status, root.Children[1].Status)
+	}
+}
+
+func TestParseOPML_ForeignAttributes(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<opml version="2.0">
+  <head><title>Imported</title></head>
+  <body>
+    <outline text="Item" _note="From another tool">
+      <outline text="Sub"/>
+    </outline>
+  </body>
+</opml>`
+
+	outline, err := ParseOPML(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	node := outline.Nodes[0]
+	if len(node.Notes) != 1 || node.Notes[0] != "From another tool" {
+		t.Errorf("expected _note to be imported, got %q", node.Notes)
+	}
+	if len(node.Children) != 1 || node.Status != StatusOpen {
+		t.Errorf("unexpected node: %+v", node)
+	}
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := ParseOPML(strings.NewReader(`<opml version="2.0"><body><outline text="x" _status="done"/></body></opml>`))
+		if err == nil {
+			t.Error("expected error for unknown status")
+		}
+	})
+}
+
+func TestTasks_CreateOutline(t *testing.T) {
+	var (
+		created     []CreateTaskRequest
+		notes       []string
+		invalidated []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case r.URL.Path == "/checklists/1/tasks.json":
+			var req createTaskWrapper
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			created = append(created, req.Task)
+			json.NewEncoder(w).Encode(Task{ID: 100 + len(created), Content: req.Task.Content})
+		case strings.HasSuffix(r.URL.Path, "/comments.json"):
+			var req createNoteRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			notes = append(notes, r.URL.Path+" "+req.Comment.Comment)
+			json.NewEncoder(w).Encode(Note{Comment: req.Comment.Comment})
+		case strings.HasSuffix(r.URL.Path, "/invalidate.json"):
+			invalidated = append(invalidated, r.URL.Path)
+			json.NewEncoder(w).Encode([]Task{{ID: 102, Status: StatusInvalidated}})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	nodes := []*OutlineNode{{
+		Task:  NewTask("Parent").WithTags("work"),
+		Notes: []string{"Remember"},
+		Children: []*OutlineNode{
+			{Task: NewTask("Child"), Status: StatusInvalidated},
+		},
+	}}
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	tasks, err := client.Tasks(1).CreateOutline(context.Background(), nodes, 0, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[1].Status != StatusInvalidated {
+		t.Errorf("expected 2 tasks with invalidated child, got %+v", tasks)
+	}
+	if created[1].ParentID != 101 {
+		t.Errorf("expected child under 101, got %d", created[1].ParentID)
+	}
+	if len(notes) != 1 || notes[0] != "/checklists/1/tasks/101/comments.json Remember" {
+		t.Errorf("unexpected notes: %v", notes)
+	}
+	if len(invalidated) != 1 || invalidated[0] != "/checklists/1/tasks/102/invalidate.json" {
+		t.Errorf("unexpected invalidate calls: %v", invalidated)
+	}
+	if nodes[0].Task.parentID != 0 {
+		t.Error("expected CreateOutline not to modify the input builders")
+	}
+}
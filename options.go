@@ -1,6 +1,7 @@
 package checkvist
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"time"
@@ -71,3 +72,74 @@ func WithBaseURL(url string) Option {
 		c.baseURL = url
 	}
 }
+
+// WithTokenStore sets a TokenStore used to persist authentication tokens.
+// A stored token is reused until it expires, so short-lived processes do not
+// need to log in on every run. Tokens are keyed by username.
+func WithTokenStore(store TokenStore) Option {
+	return func(c *Client) {
+		c.tokenStore = store
+	}
+}
+
+// WithRateLimit limits the client to perSecond requests per second on
+// average, allowing bursts of up to burst requests. The limit is shared by all
+// services of the client and applies to every attempt, including retries.
+// A rate that is not positive disables rate limiting.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		if !(perSecond > 0) {
+			c.limiter = nil
+			return
+		}
+		c.limiter = NewRateLimiter(perSecond, burst)
+	}
+}
+
+// WithRateLimiter sets the rate limiter used by the client.
+// Passing the same limiter to several clients applies a common limit to all of them.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithMiddleware adds middlewares that wrap every HTTP request sent by the
+// client, including authentication and token refresh. Middlewares run in the
+// order they are added, the first being the outermost.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithObserver sets an Observer that is notified of every HTTP request,
+// e.g. to record traces or metrics.
+func WithObserver(observer Observer) Option {
+	return func(c *Client) {
+		c.observer = observer
+	}
+}
+
+// WithCache enables conditional GET requests using the given cache.
+// Responses carrying an ETag or Last-Modified header are cached, later
+// requests send If-None-Match and If-Modified-Since, and the cached body is
+// used when the server answers 304 Not Modified. Writes made by the client
+// invalidate the cached responses of the affected checklist.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// TOTPProvider returns a current two-factor authentication code.
+// It is called whenever the client needs to log in on its own, for example
+// on the first request or after the server rejected the token.
+type TOTPProvider func(ctx context.Context) (string, error)
+
+// WithTOTPProvider sets a provider for 2FA codes used by automatic logins.
+func WithTOTPProvider(provider TOTPProvider) Option {
+	return func(c *Client) {
+		c.totpProvider = provider
+	}
+}
@@ -0,0 +1,112 @@
+package checkvist
+
+import (
+	"context"
+	"fmt"
+)
+
+// outline.go contains the OutlineNode type shared by the outline importers
+// and the logic for creating outline trees through the TaskService.
+
+// Outline is a titled tree of tasks parsed from an external format.
+type Outline struct {
+	// Title is the document title, typically the checklist name.
+	Title string
+	// Nodes contains the top-level tasks.
+	Nodes []*OutlineNode
+}
+
+// OutlineNode is a task to be created together with its notes and subtasks.
+type OutlineNode struct {
+	// Task holds the content and attributes of the task to create.
+	Task *TaskBuilder
+	// Status is applied after the task has been created and before its
+	// subtasks are, so that the subtasks keep their own status.
+	Status TaskStatus
+	// Notes contains the comments to attach to the task.
+	Notes []string
+	// Children contains the subtasks in order.
+	Children []*OutlineNode
+}
+
+// CreateOutline creates the nodes and their subtasks depth-first, wiring each
+// child to the ID of its freshly created parent. Top-level nodes are placed
+// under parentID starting at position (0 appends). Notes are attached and
+// closed or invalidated statuses are applied before a node's subtasks are
+// created, so that closing a parent does not change its subtasks' status.
+//
+// Created tasks are returned in outline order. On error, the tasks created
+// so far are returned together with the error.
+func (s *TaskService) CreateOutline(ctx context.Context, nodes []*OutlineNode, parentID, position int) ([]Task, error) {
+	var created []Task
+
+	var create func(nodes []*OutlineNode, parentID, position int) error
+	create = func(nodes []*OutlineNode, parentID, position int) error {
+		for i, node := range nodes {
+			builder := *node.Task
+			builder.parentID = parentID
+			if position > 0 {
+				builder.position = position + i
+			}
+
+			task, err := s.Create(ctx, &builder)
+			if err != nil {
+				return fmt.Errorf("creating task %q: %w", builder.content, err)
+			}
+			index := len(created)
+			created = append(created, *task)
+
+			for _, comment := range node.Notes {
+				if _, err := s.client.Notes(s.checklistID, task.ID).Create(ctx, comment); err != nil {
+					return fmt.Errorf("creating note on task %d: %w", task.ID, err)
+				}
+			}
+
+			// Closing a task also closes its open subtasks, so the status
+			// is applied before the subtasks are created.
+			var updated *Task
+			switch node.Status {
+			case StatusClosed:
+				updated, err = s.Close(ctx, task.ID)
+			case StatusInvalidated:
+				updated, err = s.Invalidate(ctx, task.ID)
+			}
+			if err != nil {
+				return fmt.Errorf("setting status of task %d: %w", task.ID, err)
+			}
+			if updated != nil {
+				created[index] = *updated
+			}
+
+			if err := create(node.Children, task.ID, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err := create(nodes, parentID, position)
+	return created, err
+}
+
+// outlineFromTree converts a task tree into outline nodes, taking notes from
+// the notes map or, if absent there, from the tasks themselves.
+func outlineFromTree(roots []*TaskNode, notes map[int][]Note) []*OutlineNode {
+	result := make([]*OutlineNode, 0, len(roots))
+	for _, root := range roots {
+		taskNotes, ok := notes[root.Task.ID]
+		if !ok {
+			taskNotes = root.Task.Notes
+		}
+		node := &OutlineNode{
+			Task:     builderFromTask(root.Task),
+			Status:   root.Task.Status,
+			Children: outlineFromTree(root.Children, notes),
+		}
+		for _, note := range taskNotes {
+			node.Notes = append(node.Notes, note.Comment)
+		}
+		result = append(result, node)
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package checkvist
+
+import (
+	"strings"
+	"time"
+)
+
+// predicate.go contains the Predicate type with its boolean combinators and
+// the predicates behind the Filter builder methods.
+
+// Predicate reports whether a task matches a condition. Predicates are
+// combined with And, Or and Not and applied with Filter.Where.
+type Predicate func(Task) bool
+
+// And returns a predicate that matches tasks matching all of the predicates.
+// It matches every task if no predicates are given.
+func And(preds ...Predicate) Predicate {
+	if len(preds) == 1 {
+		return preds[0]
+	}
+	return func(t Task) bool {
+		for _, pred := range preds {
+			if !pred(t) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a predicate that matches tasks matching any of the predicates.
+// It matches no task if no predicates are given.
+func Or(preds ...Predicate) Predicate {
+	if len(preds) == 1 {
+		return preds[0]
+	}
+	return func(t Task) bool {
+		for _, pred := range preds {
+			if pred(t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate that matches tasks not matching pred.
+func Not(pred Predicate) Predicate {
+	return func(t Task) bool {
+		return !pred(t)
+	}
+}
+
+// MatchTag matches tasks that have the specified tag (case-insensitive).
+func MatchTag(tag string) Predicate {
+	return func(t Task) bool {
+		return taskHasTag(t, tag)
+	}
+}
+
+// MatchTags matches tasks that have all of the specified tags.
+func MatchTags(tags ...string) Predicate {
+	return func(t Task) bool {
+		for _, tag := range tags {
+			if !taskHasTag(t, tag) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchStatus matches tasks with the specified status.
+func MatchStatus(status TaskStatus) Predicate {
+	return func(t Task) bool {
+		return t.Status == status
+	}
+}
+
+// MatchDueBefore matches tasks with due dates before the specified time.
+func MatchDueBefore(deadline time.Time) Predicate {
+	return func(t Task) bool {
+		if t.DueDate == nil {
+			return false
+		}
+		return t.DueDate.Before(deadline)
+	}
+}
+
+// MatchDueAfter matches tasks with due dates after the specified time.
+func MatchDueAfter(after time.Time) Predicate {
+	return func(t Task) bool {
+		if t.DueDate == nil {
+			return false
+		}
+		return t.DueDate.After(after)
+	}
+}
+
+// MatchDueOn matches tasks with due dates on the specified day.
+func MatchDueOn(day time.Time) Predicate {
+	year, month, d := day.Date()
+	return func(t Task) bool {
+		if t.DueDate == nil {
+			return false
+		}
+		ty, tm, td := t.DueDate.Date()
+		return ty == year && tm == month && td == d
+	}
+}
+
+// MatchOverdue matches open tasks whose due date is before today, in the
+// local time zone. Today is determined when MatchOverdue is called.
+func MatchOverdue() Predicate {
+	return overdueAt(time.Now())
+}
+
+// overdueAt matches open tasks due before the calendar day of now. It is
+// shared by MatchOverdue and the due:overdue query term.
+func overdueAt(now time.Time) Predicate {
+	today := startOfDay(now)
+	return func(t Task) bool {
+		if t.DueDate == nil {
+			return false
+		}
+		return startOfDay(*t.DueDate).Before(today) && t.Status == StatusOpen
+	}
+}
+
+// MatchSearch matches tasks whose content contains the query (case-insensitive).
+func MatchSearch(query string) Predicate {
+	lowerQuery := strings.ToLower(query)
+	return func(t Task) bool {
+		return strings.Contains(strings.ToLower(t.Content), lowerQuery)
+	}
+}
@@ -0,0 +1,436 @@
+package checkvist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// query.go contains ParseQuery, which compiles a Checkvist-like search
+// query into a Filter.
+
+// QueryError is returned by ParseQuery for a malformed query.
+type QueryError struct {
+	// Query is the query that failed to parse.
+	Query string
+	// Pos is the byte offset in Query at which the error was detected.
+	Pos int
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface. The reported column is Pos+1.
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("invalid query at column %d: %s", e.Pos+1, e.Message)
+}
+
+// ParseQuery compiles a search query into a Filter. Apply the result to
+// tasks with Filter.ApplyTo, or combine it with other conditions through
+// Filter.Predicate.
+//
+// A query consists of terms separated by whitespace; all terms must match.
+// The supported terms are:
+//
+//	#urgent             task has the tag (also tag:urgent)
+//	status:open         task status: open, closed (or done), invalidated
+//	due:2026-11-01      due on the day; also due:<, due:<=, due:>, due:>=
+//	due:today           relative days: today, tomorrow, yesterday
+//	due:overdue         open and due before today, as WithOverdue
+//	due:none, due:any   task has no due date or any due date
+//	priority:1          priority; also with <, <=, >, >=
+//	report, "q4 report" content contains the word or phrase (case-insensitive)
+//
+// Terms are negated with a leading "-" or NOT, combined with OR and grouped
+// with parentheses; NOT binds tighter than AND, which binds tighter than OR:
+//
+//	#urgent status:open due:<2026-11-01 priority:1 -#someday (foo OR bar)
+//
+// Field names and keywords other than AND, OR and NOT are case-insensitive.
+// Values containing spaces can be quoted, as in tag:"next week". Relative
+// due dates are resolved when the query is parsed. An empty query matches
+// every task. Malformed queries return a *QueryError.
+func ParseQuery(query string) (*Filter, error) {
+	p := &queryParser{query: query, now: time.Now()}
+	if err := p.scan(); err != nil {
+		return nil, err
+	}
+
+	f := NewFilter(nil)
+	if len(p.tokens) == 1 {
+		return f, nil
+	}
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.errorf(tok.pos, "unexpected %s", tok)
+	}
+	return f.Where(match), nil
+}
+
+// tokenKind identifies the type of a query token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTerm
+	tokenPhrase
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+// queryToken is a lexical token of a query.
+type queryToken struct {
+	kind tokenKind
+	pos  int
+	// text is the unquoted text of a term or phrase.
+	text string
+	// quoted holds, for each byte of text, whether it came from a quoted section.
+	quoted []bool
+}
+
+// String describes the token for error messages.
+func (t queryToken) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	case tokenLParen:
+		return `"("`
+	case tokenRParen:
+		return `")"`
+	}
+	return strconv.Quote(t.text)
+}
+
+// queryParser is a recursive descent parser for search queries.
+type queryParser struct {
+	query  string
+	now    time.Time
+	tokens []queryToken
+	next   int
+}
+
+// errorf returns a QueryError at the given byte offset.
+func (p *queryParser) errorf(pos int, format string, args ...any) error {
+	return &QueryError{Query: p.query, Pos: pos, Message: fmt.Sprintf(format, args...)}
+}
+
+// scan splits the query into tokens, ending with a tokenEOF.
+func (p *queryParser) scan() error {
+	q := p.query
+	i := 0
+	for i < len(q) {
+		switch c := q[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens, queryToken{kind: tokenLParen, pos: i})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens, queryToken{kind: tokenRParen, pos: i})
+			i++
+		case c == '-':
+			if i+1 == len(q) || strings.ContainsRune(" \t\n\r)", rune(q[i+1])) {
+				return p.errorf(i, `expected a term after "-"`)
+			}
+			p.tokens = append(p.tokens, queryToken{kind: tokenNot, pos: i})
+			i++
+		default:
+			tok, end, err := p.scanWord(i)
+			if err != nil {
+				return err
+			}
+			p.tokens = append(p.tokens, tok)
+			i = end
+		}
+	}
+	p.tokens = append(p.tokens, queryToken{kind: tokenEOF, pos: len(q)})
+	return nil
+}
+
+// scanWord scans a term starting at offset start, which ends at whitespace or
+// a parenthesis outside quotes. It returns the token and the end offset.
+func (p *queryParser) scanWord(start int) (queryToken, int, error) {
+	q := p.query
+	var text strings.Builder
+	var quoted []bool
+	anyQuoted := false
+
+	i := start
+	for i < len(q) && !strings.ContainsRune(" \t\n\r()", rune(q[i])) {
+		if q[i] != '"' {
+			text.WriteByte(q[i])
+			quoted = append(quoted, false)
+			i++
+			continue
+		}
+		end := strings.IndexByte(q[i+1:], '"')
+		if end < 0 {
+			return queryToken{}, 0, p.errorf(i, "unterminated quoted string")
+		}
+		text.WriteString(q[i+1 : i+1+end])
+		for j := 0; j < end; j++ {
+			quoted = append(quoted, true)
+		}
+		anyQuoted = true
+		i += end + 2
+	}
+
+	tok := queryToken{kind: tokenTerm, pos: start, text: text.String(), quoted: quoted}
+	switch {
+	case anyQuoted && q[start] == '"' && i == start+len(tok.text)+2:
+		tok.kind = tokenPhrase
+	case !anyQuoted && tok.text == "AND":
+		tok.kind = tokenAnd
+	case !anyQuoted && tok.text == "OR":
+		tok.kind = tokenOr
+	case !anyQuoted && tok.text == "NOT":
+		tok.kind = tokenNot
+	}
+	return tok, i, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+// consume returns the next token and advances past it.
+func (p *queryParser) consume() queryToken {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *queryParser) parseOr() (Predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	alternatives := []Predicate{left}
+	for p.peek().kind == tokenOr {
+		p.consume()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, right)
+	}
+	return Or(alternatives...), nil
+}
+
+// parseAnd parses: unary (["AND"] unary)*
+func (p *queryParser) parseAnd() (Predicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	terms := []Predicate{left}
+	for {
+		switch p.peek().kind {
+		case tokenEOF, tokenOr, tokenRParen:
+			return And(terms...), nil
+		case tokenAnd:
+			p.consume()
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, right)
+	}
+}
+
+// parseUnary parses: ("NOT" | "-") unary | primary
+func (p *queryParser) parseUnary() (Predicate, error) {
+	if p.peek().kind != tokenNot {
+		return p.parsePrimary()
+	}
+	p.consume()
+	match, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return Not(match), nil
+}
+
+// parsePrimary parses: "(" or ")" | term | phrase
+func (p *queryParser) parsePrimary() (Predicate, error) {
+	tok := p.consume()
+	switch tok.kind {
+	case tokenLParen:
+		match, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, p.errorf(tok.pos, "unclosed parenthesis")
+		}
+		p.consume()
+		return match, nil
+	case tokenTerm:
+		return p.compileTerm(tok)
+	case tokenPhrase:
+		return MatchSearch(tok.text), nil
+	}
+	return nil, p.errorf(tok.pos, "expected a term, found %s", tok)
+}
+
+// compileTerm compiles a tag, field or word term.
+func (p *queryParser) compileTerm(tok queryToken) (Predicate, error) {
+	if strings.HasPrefix(tok.text, "#") && !tok.quoted[0] {
+		if len(tok.text) == 1 {
+			return nil, p.errorf(tok.pos, `expected a tag name after "#"`)
+		}
+		return MatchTag(tok.text[1:]), nil
+	}
+
+	colon := strings.IndexByte(tok.text, ':')
+	if colon <= 0 || tok.quoted[colon] {
+		return MatchSearch(tok.text), nil
+	}
+	field := strings.ToLower(tok.text[:colon])
+	value := tok.text[colon+1:]
+	valuePos := tok.pos + colon + 1
+	if value == "" {
+		return nil, p.errorf(valuePos, "expected a value for %q", field)
+	}
+
+	switch field {
+	case "tag":
+		return MatchTag(value), nil
+	case "status":
+		return p.compileStatus(value, valuePos)
+	case "due":
+		return p.compileDue(value, valuePos)
+	case "priority":
+		return p.compilePriority(value, valuePos)
+	case "text", "content":
+		return MatchSearch(value), nil
+	}
+	return nil, p.errorf(tok.pos, "unknown field %q", field)
+}
+
+// compileStatus compiles a status:value term.
+func (p *queryParser) compileStatus(value string, pos int) (Predicate, error) {
+	switch strings.ToLower(value) {
+	case "open":
+		return MatchStatus(StatusOpen), nil
+	case "closed", "done":
+		return MatchStatus(StatusClosed), nil
+	case "invalidated":
+		return MatchStatus(StatusInvalidated), nil
+	}
+	return nil, p.errorf(pos, "unknown status %q", value)
+}
+
+// compileDue compiles a due:value term.
+func (p *queryParser) compileDue(value string, pos int) (Predicate, error) {
+	switch strings.ToLower(value) {
+	case "none":
+		return func(t Task) bool { return t.DueDate == nil }, nil
+	case "any":
+		return func(t Task) bool { return t.DueDate != nil }, nil
+	case "overdue":
+		return overdueAt(p.now), nil
+	}
+
+	op, rest := splitOperator(value)
+	if rest == "" {
+		return nil, p.errorf(pos+len(op), "expected a date after %q", op)
+	}
+	day, ok := p.parseDay(rest)
+	if !ok {
+		return nil, p.errorf(pos+len(op), "invalid date %q: use YYYY-MM-DD, today, tomorrow or yesterday", rest)
+	}
+	return func(t Task) bool {
+		if t.DueDate == nil {
+			return false
+		}
+		return compareOp(op, startOfDay(*t.DueDate).Compare(day))
+	}, nil
+}
+
+// compilePriority compiles a priority:value term.
+func (p *queryParser) compilePriority(value string, pos int) (Predicate, error) {
+	op, rest := splitOperator(value)
+	priority, err := strconv.Atoi(rest)
+	if err != nil || priority < 0 {
+		return nil, p.errorf(pos+len(op), "invalid priority %q", rest)
+	}
+	return func(t Task) bool {
+		switch {
+		case t.Priority < priority:
+			return compareOp(op, -1)
+		case t.Priority > priority:
+			return compareOp(op, 1)
+		}
+		return compareOp(op, 0)
+	}, nil
+}
+
+// parseDay parses an absolute or relative date into the start of its day in UTC.
+func (p *queryParser) parseDay(s string) (time.Time, bool) {
+	today := startOfDay(p.now)
+	switch strings.ToLower(s) {
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+	for _, layout := range []string{"2006-01-02", "2006/01/02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// splitOperator splits a comparison operator off the front of a value.
+func splitOperator(value string) (op, rest string) {
+	for _, op := range []string{"<=", ">=", "<", ">", "="} {
+		if rest, ok := strings.CutPrefix(value, op); ok {
+			return op, rest
+		}
+	}
+	return "", value
+}
+
+// compareOp reports whether a comparison result (-1, 0 or 1) satisfies op.
+// An empty op means equality.
+func compareOp(op string, cmp int) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return cmp == 0
+}
+
+// startOfDay returns midnight UTC of the calendar day of t in its own
+// location, so that due dates and query dates compare by day.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
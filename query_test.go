@@ -0,0 +1,148 @@
+package checkvist
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func queryTestTasks() []Task {
+	due := func(s string) *time.Time {
+		t, _ := time.Parse("2006-01-02", s)
+		return &t
+	}
+	return []Task{
+		{ID: 1, Content: "Write foo report", TagsAsText: "urgent", Priority: 1, DueDate: due("2026-10-20")},
+		{ID: 2, Content: "Review bar", TagsAsText: "urgent, someday", Priority: 1, DueDate: due("2026-11-05")},
+		{ID: 3, Content: "Call Bob", TagsAsText: "next week", Status: StatusClosed, Priority: 2},
+		{ID: 4, Content: "Plan Q4: budget", TagsAsText: "", Status: StatusInvalidated, DueDate: due("2026-11-01")},
+		{ID: 5, Content: "Buy milk", TagsAsText: "errand", DueDate: due("2020-01-01")},
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tasks := queryTestTasks()
+
+	tests := []struct {
+		query    string
+		expected []int
+	}{
+		{"", []int{1, 2, 3, 4, 5}},
+		{"#urgent", []int{1, 2}},
+		{"#URGENT -#someday", []int{1}},
+		{"tag:urgent NOT tag:someday", []int{1}},
+		{`tag:"next week"`, []int{3}},
+		{"status:open", []int{1, 2, 5}},
+		{"status:done", []int{3}},
+		{"Status:Invalidated", []int{4}},
+		{"due:2026-11-01", []int{4}},
+		{"due:<2026-11-01", []int{1, 5}},
+		{"due:<=2026-11-01", []int{1, 4, 5}},
+		{"due:>2026-11-01", []int{2}},
+		{"due:>=2026/11/01", []int{2, 4}},
+		{"due:none", []int{3}},
+		{"due:any", []int{1, 2, 4, 5}},
+		{"due:overdue", []int{5}},
+		{"priority:1", []int{1, 2}},
+		{"priority:>1", []int{3}},
+		{"priority:<=1", []int{1, 2, 4, 5}},
+		{"foo", []int{1}},
+		{"foo OR bar", []int{1, 2}},
+		{`"call bob"`, []int{3}},
+		{`"Q4:"`, []int{4}},
+		{"content:milk", []int{5}},
+		{"#urgent status:open due:<2026-11-01 priority:1 -#someday (foo OR bar)", []int{1}},
+		{"#urgent OR #errand status:open", []int{1, 2, 5}},
+		{"(#urgent OR #errand) AND due:<2026-11-01", []int{1, 5}},
+		{"-(#urgent OR status:open)", []int{3, 4}},
+		{"NOT NOT #errand", []int{5}},
+		{`"OR" #errand`, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			f, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			result := f.ApplyTo(tasks)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("expected tasks %v, got %d tasks: %+v", tt.expected, len(result), result)
+			}
+			for i, task := range result {
+				if task.ID != tt.expected[i] {
+					t.Errorf("expected task ID %d at index %d, got %d", tt.expected[i], i, task.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestParseQuery_Relative(t *testing.T) {
+	today := startOfDay(time.Now())
+	tomorrow := today.AddDate(0, 0, 1)
+	tasks := []Task{
+		{ID: 1, DueDate: &today},
+		{ID: 2, DueDate: &tomorrow},
+	}
+
+	for query, want := range map[string]int{"due:today": 1, "due:tomorrow": 1, "due:<today": 0, "due:>yesterday": 2} {
+		f, err := ParseQuery(query)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", query, err)
+		}
+		if got := len(f.ApplyTo(tasks)); got != want {
+			t.Errorf("%s: expected %d tasks, got %d", query, want, got)
+		}
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{"foo (bar", 4},
+		{"foo bar)", 7},
+		{")", 0},
+		{"()", 1},
+		{"foo OR", 6},
+		{"OR foo", 0},
+		{"foo AND AND bar", 8},
+		{"foo - bar", 4},
+		{"foo -", 4},
+		{`#urgent "unterminated`, 8},
+		{"# foo", 0},
+		{"status:", 7},
+		{"status:maybe", 7},
+		{"color:red", 0},
+		{"due:<", 5},
+		{"due:<next-week", 5},
+		{"priority:high", 9},
+		{"priority:>=x", 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			var qerr *QueryError
+			if !errors.As(err, &qerr) {
+				t.Fatalf("expected *QueryError, got %v", err)
+			}
+			if qerr.Pos != tt.pos {
+				t.Errorf("expected error at %d, got %d (%v)", tt.pos, qerr.Pos, err)
+			}
+			if qerr.Query != tt.query {
+				t.Errorf("expected query %q in error, got %q", tt.query, qerr.Query)
+			}
+		})
+	}
+}
+
+func TestQueryError_Error(t *testing.T) {
+	_, err := ParseQuery("status:maybe")
+	want := `invalid query at column 8: unknown status "maybe"`
+	if err == nil || err.Error() != want {
+		t.Errorf("expected %q, got %v", want, err)
+	}
+}
@@ -0,0 +1,118 @@
+package checkvist
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// ratelimit.go contains the client-side token bucket rate limiter.
+
+// RateLimiter is a token bucket limiter that spaces out requests to the API.
+// The bucket holds up to burst tokens and is refilled at the configured rate;
+// each request takes one token and waits if none is available.
+//
+// A RateLimiter is safe for concurrent use and may be shared between clients
+// to apply a common limit, e.g. via WithRateLimiter.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing perSecond requests per second on
+// average and bursts of up to burst requests. A burst below 1 is treated as 1.
+// A rate that is not positive disables the limit, so requests never wait.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	if !(perSecond > 0) {
+		perSecond = math.Inf(1)
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds the tokens accumulated since the last update. Callers must hold mu.
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+// delayFor returns how long it takes until the bucket holds n tokens.
+// Callers must hold mu.
+func (l *RateLimiter) delayFor(n float64) time.Duration {
+	if l.tokens >= n || math.IsInf(l.rate, 1) {
+		return 0
+	}
+	return time.Duration((n - l.tokens) / l.rate * float64(time.Second))
+}
+
+// Delay returns how long a request made now would have to wait.
+// It does not take a token and is intended for logging and metrics.
+func (l *RateLimiter) Delay() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	return l.delayFor(1)
+}
+
+// Wait takes a token, blocking until one is available or ctx is done.
+// If ctx is done first, the reserved token is returned to the bucket and
+// the context's error is returned.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	l.refill(time.Now())
+	delay := l.delayFor(1)
+	l.tokens--
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens = min(l.burst, l.tokens+1)
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RateLimitDelay returns how long the next request would currently wait for
+// the client's rate limiter, or zero if no limiter is configured.
+func (c *Client) RateLimitDelay() time.Duration {
+	if c.limiter == nil {
+		return 0
+	}
+	return c.limiter.Delay()
+}
+
+// waitForRateLimit blocks until the rate limiter, if any, admits a request.
+func (c *Client) waitForRateLimit(ctx context.Context, path string) error {
+	if c.limiter == nil {
+		return nil
+	}
+	if delay := c.limiter.Delay(); delay > 0 {
+		c.logger.Debug("waiting for rate limiter",
+			"delay", delay,
+			"path", path,
+		)
+	}
+	return c.limiter.Wait(ctx)
+}
@@ -0,0 +1,117 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter := NewRateLimiter(10, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected burst to pass without waiting, took %v", elapsed)
+	}
+
+	if delay := limiter.Delay(); delay <= 0 || delay > 100*time.Millisecond {
+		t.Errorf("expected a delay of up to 100ms, got %v", delay)
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("expected fourth request to wait, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_NonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		limiter := NewRateLimiter(rate, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		for i := 0; i < 5; i++ {
+			if err := limiter.Wait(ctx); err != nil {
+				t.Fatalf("rate %v: expected requests not to wait, got %v", rate, err)
+			}
+		}
+		cancel()
+		if delay := limiter.Delay(); delay != 0 {
+			t.Errorf("rate %v: expected no delay, got %v", rate, delay)
+		}
+
+		if client := NewClient("user@example.com", "api-key", WithRateLimit(rate, 1)); client.limiter != nil {
+			t.Errorf("rate %v: expected WithRateLimit to disable the limiter", rate)
+		}
+	}
+}
+
+func TestRateLimiter_ContextCancel(t *testing.T) {
+	limiter := NewRateLimiter(1, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// The cancelled reservation is returned, so the next request waits
+	// for the original token rather than a second one
+	if delay := limiter.Delay(); delay > time.Second {
+		t.Errorf("expected cancelled reservation to be released, got delay %v", delay)
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists.json", "/checklists/1/tasks.json", "/checklists/1/tasks/1/comments.json":
+			atomic.AddInt32(&requests, 1)
+			w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL), WithRateLimit(20, 1))
+	ctx := context.Background()
+
+	start := time.Now()
+	if _, err := client.Checklists().List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Tasks(1).List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Notes(1, 1).List(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Three requests with a burst of one need two refills of 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected services to share the limiter, took %v", elapsed)
+	}
+	if client.RateLimitDelay() == 0 {
+		t.Errorf("expected a pending delay right after the last request")
+	}
+	if NewClient("u", "k").RateLimitDelay() != 0 {
+		t.Errorf("expected no delay without a limiter")
+	}
+}
@@ -0,0 +1,271 @@
+package checkvist
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sort.go contains multi-key sorting and grouping of tasks for building
+// agenda-style views from filter results.
+
+// SortField is a task field that tasks can be sorted by.
+type SortField string
+
+// Fields supported by SortTasks and Filter.SortBy.
+const (
+	// SortDue sorts by due date. Tasks without a due date always sort last,
+	// in both directions.
+	SortDue SortField = "due"
+	// SortPriority sorts from the highest priority (1) to normal (0) in
+	// ascending order.
+	SortPriority SortField = "priority"
+	// SortPosition sorts by position within the parent task.
+	SortPosition SortField = "position"
+	// SortUpdatedAt sorts by the time of the last update.
+	SortUpdatedAt SortField = "updated_at"
+	// SortCreatedAt sorts by the creation time.
+	SortCreatedAt SortField = "created_at"
+	// SortContent sorts alphabetically by content, ignoring case.
+	SortContent SortField = "content"
+)
+
+// SortKey is a sort field with a direction.
+type SortKey struct {
+	// Field is the field to compare.
+	Field SortField
+	// Descending reverses the order of the field.
+	Descending bool
+}
+
+// Asc returns a SortKey sorting by field in ascending order.
+func Asc(field SortField) SortKey {
+	return SortKey{Field: field}
+}
+
+// Desc returns a SortKey sorting by field in descending order.
+func Desc(field SortField) SortKey {
+	return SortKey{Field: field, Descending: true}
+}
+
+// SortBy sorts the result of Apply by the given keys. Later keys break ties
+// of earlier keys; tasks that compare equal on all keys keep their original
+// order. Calling SortBy again replaces the keys.
+func (f *Filter) SortBy(keys ...SortKey) *Filter {
+	f.sortKeys = keys
+	return f
+}
+
+// SortTasks sorts tasks in place by the given keys, as Filter.SortBy.
+// Unknown fields are ignored.
+func SortTasks(tasks []Task, keys ...SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, key := range keys {
+			if cmp := compareTasks(tasks[i], tasks[j], key); cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+}
+
+// compareTasks compares two tasks by a sort key and returns -1, 0 or 1.
+func compareTasks(a, b Task, key SortKey) int {
+	var cmp int
+	switch key.Field {
+	case SortDue:
+		// Missing due dates sort last regardless of the direction.
+		switch {
+		case a.DueDate == nil && b.DueDate == nil:
+			return 0
+		case a.DueDate == nil:
+			return 1
+		case b.DueDate == nil:
+			return -1
+		}
+		cmp = a.DueDate.Compare(*b.DueDate)
+	case SortPriority:
+		cmp = compareInts(priorityRank(a.Priority), priorityRank(b.Priority))
+	case SortPosition:
+		cmp = compareInts(a.Position, b.Position)
+	case SortUpdatedAt:
+		cmp = a.UpdatedAt.Compare(b.UpdatedAt.Time)
+	case SortCreatedAt:
+		cmp = a.CreatedAt.Compare(b.CreatedAt.Time)
+	case SortContent:
+		cmp = strings.Compare(strings.ToLower(a.Content), strings.ToLower(b.Content))
+	}
+	if key.Descending {
+		return -cmp
+	}
+	return cmp
+}
+
+// priorityRank maps a priority to its rank, where normal priority (0)
+// ranks after every explicit priority.
+func priorityRank(priority int) int {
+	if priority <= 0 {
+		return int(^uint(0) >> 1)
+	}
+	return priority
+}
+
+// compareInts returns -1, 0 or 1.
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// GroupField is a task property that tasks can be grouped by.
+type GroupField string
+
+// Properties supported by GroupTasks and Filter.GroupBy.
+const (
+	// GroupByTag groups tasks by tag in alphabetical order. Tasks with several
+	// tags appear in each of their groups; untagged tasks form a last group
+	// with an empty key.
+	GroupByTag GroupField = "tag"
+	// GroupByStatus groups tasks by status in the order open, closed,
+	// invalidated. The keys are the status names.
+	GroupByStatus GroupField = "status"
+	// GroupByParent groups tasks by parent task ID in the order in which the
+	// parents first appear. The key of root tasks is "0".
+	GroupByParent GroupField = "parent"
+	// GroupByDueBucket groups tasks by due date relative to today. The keys
+	// are the DueBucket constants, in the order they are declared.
+	GroupByDueBucket GroupField = "due-bucket"
+)
+
+// Due date buckets used as group keys by GroupByDueBucket.
+const (
+	// DueBucketOverdue contains tasks due before today.
+	DueBucketOverdue = "overdue"
+	// DueBucketToday contains tasks due today.
+	DueBucketToday = "today"
+	// DueBucketTomorrow contains tasks due tomorrow.
+	DueBucketTomorrow = "tomorrow"
+	// DueBucketNextWeek contains tasks due in two to seven days.
+	DueBucketNextWeek = "next-week"
+	// DueBucketLater contains tasks due in more than seven days.
+	DueBucketLater = "later"
+	// DueBucketNone contains tasks without a due date.
+	DueBucketNone = "none"
+)
+
+// TaskGroup is a group of tasks sharing a key.
+type TaskGroup struct {
+	// Key identifies the group: a tag, status name, parent ID or due bucket.
+	Key string
+	// Tasks contains the tasks of the group in their original order.
+	Tasks []Task
+}
+
+// GroupBy applies the filter, including any sort keys, and groups the result.
+// Within each group, tasks keep the order of the result.
+func (f *Filter) GroupBy(field GroupField) []TaskGroup {
+	return GroupTasks(f.Apply(), field)
+}
+
+// GroupTasks groups tasks by the given property, as Filter.GroupBy. Empty
+// groups are omitted. It returns nil for an unknown field.
+func GroupTasks(tasks []Task, field GroupField) []TaskGroup {
+	var keys func(Task) []string
+	var order []string
+
+	switch field {
+	case GroupByTag:
+		keys = func(t Task) []string {
+			tags := t.Tags
+			if tags == nil {
+				tags = ParseTags(t.TagsAsText)
+			}
+			if len(tags) == 0 {
+				return []string{""}
+			}
+			return tags.Sorted()
+		}
+	case GroupByStatus:
+		order = []string{StatusOpen.String(), StatusClosed.String(), StatusInvalidated.String()}
+		keys = func(t Task) []string {
+			return []string{t.Status.String()}
+		}
+	case GroupByParent:
+		keys = func(t Task) []string {
+			return []string{strconv.Itoa(t.ParentID)}
+		}
+	case GroupByDueBucket:
+		order = []string{DueBucketOverdue, DueBucketToday, DueBucketTomorrow, DueBucketNextWeek, DueBucketLater, DueBucketNone}
+		today := startOfDay(time.Now())
+		keys = func(t Task) []string {
+			return []string{dueBucket(t, today)}
+		}
+	default:
+		return nil
+	}
+
+	var groups []TaskGroup
+	index := make(map[string]int)
+	for _, task := range tasks {
+		for _, key := range keys(task) {
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, TaskGroup{Key: key})
+			}
+			groups[i].Tasks = append(groups[i].Tasks, task)
+		}
+	}
+
+	switch {
+	case order != nil:
+		rank := make(map[string]int, len(order))
+		for i, key := range order {
+			rank[key] = i
+		}
+		sort.SliceStable(groups, func(i, j int) bool {
+			ri, oki := rank[groups[i].Key]
+			rj, okj := rank[groups[j].Key]
+			if oki != okj {
+				return oki
+			}
+			return ri < rj
+		})
+	case field == GroupByTag:
+		sort.SliceStable(groups, func(i, j int) bool {
+			if (groups[i].Key == "") != (groups[j].Key == "") {
+				return groups[j].Key == ""
+			}
+			return groups[i].Key < groups[j].Key
+		})
+	}
+	return groups
+}
+
+// dueBucket returns the due date bucket of a task relative to today.
+func dueBucket(t Task, today time.Time) string {
+	if t.DueDate == nil {
+		return DueBucketNone
+	}
+	days := int(startOfDay(*t.DueDate).Sub(today).Hours() / 24)
+	switch {
+	case days < 0:
+		return DueBucketOverdue
+	case days == 0:
+		return DueBucketToday
+	case days == 1:
+		return DueBucketTomorrow
+	case days <= 7:
+		return DueBucketNextWeek
+	}
+	return DueBucketLater
+}
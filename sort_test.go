@@ -0,0 +1,156 @@
+package checkvist
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func taskIDs(tasks []Task) []int {
+	ids := make([]int, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestSortTasks(t *testing.T) {
+	day := func(s string) *time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return &d
+	}
+	stamp := func(s string) APITime {
+		d, _ := time.Parse("2006-01-02", s)
+		return NewAPITime(d)
+	}
+	tasks := []Task{
+		{ID: 1, Content: "banana", Priority: 0, Position: 3, DueDate: day("2026-11-02"), UpdatedAt: stamp("2026-01-03"), CreatedAt: stamp("2025-01-01")},
+		{ID: 2, Content: "Apple", Priority: 2, Position: 1, UpdatedAt: stamp("2026-01-01"), CreatedAt: stamp("2025-01-03")},
+		{ID: 3, Content: "cherry", Priority: 1, Position: 2, DueDate: day("2026-11-01"), UpdatedAt: stamp("2026-01-02"), CreatedAt: stamp("2025-01-02")},
+		{ID: 4, Content: "apple", Priority: 1, Position: 4, DueDate: day("2026-11-02")},
+		{ID: 5, Content: "date", Priority: 0, Position: 5},
+	}
+
+	tests := []struct {
+		name     string
+		keys     []SortKey
+		expected []int
+	}{
+		{"no keys", nil, []int{1, 2, 3, 4, 5}},
+		{"due ascending", []SortKey{Asc(SortDue)}, []int{3, 1, 4, 2, 5}},
+		{"due descending keeps nil last", []SortKey{Desc(SortDue)}, []int{1, 4, 3, 2, 5}},
+		{"priority", []SortKey{Asc(SortPriority)}, []int{3, 4, 2, 1, 5}},
+		{"priority descending", []SortKey{Desc(SortPriority)}, []int{1, 5, 2, 3, 4}},
+		{"position", []SortKey{Asc(SortPosition)}, []int{2, 3, 1, 4, 5}},
+		{"updated at descending", []SortKey{Desc(SortUpdatedAt)}, []int{1, 3, 2, 4, 5}},
+		{"created at", []SortKey{Asc(SortCreatedAt)}, []int{4, 5, 1, 3, 2}},
+		{"content ignores case", []SortKey{Asc(SortContent)}, []int{2, 4, 1, 3, 5}},
+		{"due then priority", []SortKey{Asc(SortDue), Asc(SortPriority)}, []int{3, 4, 1, 2, 5}},
+		{"priority then content descending", []SortKey{Asc(SortPriority), Desc(SortContent)}, []int{3, 4, 2, 5, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted := append([]Task(nil), tasks...)
+			SortTasks(sorted, tt.keys...)
+			if got := taskIDs(sorted); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFilter_SortBy(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, Content: "b", TagsAsText: "work"},
+		{ID: 2, Content: "c", TagsAsText: "home"},
+		{ID: 3, Content: "a", TagsAsText: "work"},
+	}
+
+	f := NewFilter(tasks).WithTag("work").SortBy(Asc(SortContent))
+	if got := taskIDs(f.Apply()); !reflect.DeepEqual(got, []int{3, 1}) {
+		t.Errorf("expected [3 1], got %v", got)
+	}
+	if got := taskIDs(tasks); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("expected input to be unchanged, got %v", got)
+	}
+
+	f.SortBy(Desc(SortContent))
+	if got := taskIDs(f.Apply()); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("expected SortBy to replace the keys, got %v", got)
+	}
+}
+
+func TestGroupTasks(t *testing.T) {
+	today := startOfDay(time.Now())
+	in := func(days int) *time.Time {
+		d := today.AddDate(0, 0, days)
+		return &d
+	}
+	tasks := []Task{
+		{ID: 1, ParentID: 10, TagsAsText: "work, urgent", DueDate: in(3)},
+		{ID: 2, ParentID: 0, TagsAsText: "", Status: StatusClosed, DueDate: in(-1)},
+		{ID: 3, ParentID: 10, Tags: Tags{"home": true}, Status: StatusInvalidated},
+		{ID: 4, ParentID: 20, TagsAsText: "work", DueDate: in(0)},
+		{ID: 5, ParentID: 0, TagsAsText: "", DueDate: in(30)},
+		{ID: 6, ParentID: 20, TagsAsText: "", DueDate: in(1)},
+	}
+
+	type group struct {
+		key string
+		ids []int
+	}
+	tests := []struct {
+		field    GroupField
+		expected []group
+	}{
+		{GroupByTag, []group{{"home", []int{3}}, {"urgent", []int{1}}, {"work", []int{1, 4}}, {"", []int{2, 5, 6}}}},
+		{GroupByStatus, []group{{"open", []int{1, 4, 5, 6}}, {"closed", []int{2}}, {"invalidated", []int{3}}}},
+		{GroupByParent, []group{{"10", []int{1, 3}}, {"0", []int{2, 5}}, {"20", []int{4, 6}}}},
+		{GroupByDueBucket, []group{
+			{DueBucketOverdue, []int{2}},
+			{DueBucketToday, []int{4}},
+			{DueBucketTomorrow, []int{6}},
+			{DueBucketNextWeek, []int{1}},
+			{DueBucketLater, []int{5}},
+			{DueBucketNone, []int{3}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.field), func(t *testing.T) {
+			groups := GroupTasks(tasks, tt.field)
+			got := make([]group, len(groups))
+			for i, g := range groups {
+				got[i] = group{g.Key, taskIDs(g.Tasks)}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if groups := GroupTasks(tasks, "color"); groups != nil {
+		t.Errorf("expected nil for unknown field, got %v", groups)
+	}
+}
+
+func TestFilter_GroupBy(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, Content: "b", Status: StatusClosed},
+		{ID: 2, Content: "c"},
+		{ID: 3, Content: "a"},
+		{ID: 4, Content: "d", Status: StatusInvalidated},
+	}
+
+	groups := NewFilter(tasks).
+		Where(Not(MatchStatus(StatusInvalidated))).
+		SortBy(Asc(SortContent)).
+		GroupBy(GroupByStatus)
+	if len(groups) != 2 || groups[0].Key != "open" || groups[1].Key != "closed" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	if got := taskIDs(groups[0].Tasks); !reflect.DeepEqual(got, []int{3, 2}) {
+		t.Errorf("expected sorted open tasks [3 2], got %v", got)
+	}
+}
@@ -0,0 +1,120 @@
+package checkvist_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"code.beautifulmachines.dev/jakoubek/checkvist-api"
+	"code.beautifulmachines.dev/jakoubek/checkvist-api/checkvisttest"
+)
+
+// This file tests that copied task trees keep the status of every task,
+// against the fake server, which cascades closing a task to its subtasks.
+
+// seedMixedStatus adds a closed parent with an open and an invalidated child.
+func seedMixedStatus(server *checkvisttest.Server, name string) (checkvist.Checklist, checkvist.Task) {
+	cl := server.AddChecklist(name)
+	parent := server.AddTask(cl.ID, checkvist.Task{Content: "Parent", Status: checkvist.StatusClosed})
+	server.AddTask(cl.ID, checkvist.Task{Content: "Open child", ParentID: parent.ID})
+	server.AddTask(cl.ID, checkvist.Task{Content: "Invalid child", ParentID: parent.ID, Status: checkvist.StatusInvalidated})
+	return cl, parent
+}
+
+// assertMixedStatus checks the statuses created by seedMixedStatus.
+func assertMixedStatus(t *testing.T, tasks []checkvist.Task) {
+	t.Helper()
+	want := map[string]checkvist.TaskStatus{
+		"Parent":        checkvist.StatusClosed,
+		"Open child":    checkvist.StatusOpen,
+		"Invalid child": checkvist.StatusInvalidated,
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("expected %d tasks, got %+v", len(want), tasks)
+	}
+	for _, task := range tasks {
+		if task.Status != want[task.Content] {
+			t.Errorf("task %q: expected status %v, got %v", task.Content, want[task.Content], task.Status)
+		}
+	}
+}
+
+func TestMove_Copy_PreservesChildStatus(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	src, parent := seedMixedStatus(server, "Source")
+	assertMixedStatus(t, server.Tasks(src.ID))
+	dst := server.AddChecklist("Destination")
+
+	if _, err := client.Tasks(src.ID).Move(context.Background(), parent.ID, checkvist.MoveTarget{ChecklistID: dst.ID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertMixedStatus(t, server.Tasks(dst.ID))
+}
+
+func TestClone_PreservesChildStatus(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	src, _ := seedMixedStatus(server, "Source")
+
+	clone, err := client.Checklists().Clone(context.Background(), src.ID, checkvist.CloneOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertMixedStatus(t, server.Tasks(clone.ID))
+}
+
+func TestRestore_PreservesChildStatus(t *testing.T) {
+	server := checkvisttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	src, _ := seedMixedStatus(server, "Source")
+
+	backup, err := client.Backup(context.Background(), checkvist.BackupOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := client.Restore(context.Background(), backup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertMixedStatus(t, server.Tasks(result.Checklists[src.ID]))
+}
+
+func TestCreateOutline_RoundTripPreservesChildStatus(t *testing.T) {
+	formats := []struct {
+		name   string
+		export func(io.Writer, checkvist.Checklist, []checkvist.Task, map[int][]checkvist.Note) error
+		parse  func(io.Reader) (*checkvist.Outline, error)
+	}{
+		{"markdown", checkvist.ExportMarkdown, checkvist.ParseMarkdown},
+		{"opml", checkvist.ExportOPML, checkvist.ParseOPML},
+	}
+
+	for _, format := range formats {
+		t.Run(format.name, func(t *testing.T) {
+			server := checkvisttest.NewServer()
+			defer server.Close()
+			client := server.Client()
+			src, _ := seedMixedStatus(server, "Source")
+
+			var buf bytes.Buffer
+			if err := format.export(&buf, src, server.Tasks(src.ID), nil); err != nil {
+				t.Fatal(err)
+			}
+			outline, err := format.parse(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dst := server.AddChecklist("Imported")
+			if _, err := client.Tasks(dst.ID).CreateOutline(context.Background(), outline.Nodes, 0, 0); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertMixedStatus(t, server.Tasks(dst.ID))
+		})
+	}
+}
@@ -31,9 +31,8 @@ func (s *TaskService) List(ctx context.Context) ([]Task, error) {
 		return nil, err
 	}
 
-	// Parse due dates
 	for i := range tasks {
-		parseDueDate(&tasks[i])
+		parseTask(&tasks[i])
 	}
 
 	return tasks, nil
@@ -48,7 +47,7 @@ func (s *TaskService) Get(ctx context.Context, taskID int) (*Task, error) {
 		return nil, err
 	}
 
-	parseDueDate(&task)
+	parseTask(&task)
 	return &task, nil
 }
 
@@ -161,7 +160,7 @@ func (s *TaskService) Create(ctx context.Context, builder *TaskBuilder) (*Task,
 		return nil, err
 	}
 
-	parseDueDate(&task)
+	parseTask(&task)
 	return &task, nil
 }
 
@@ -190,10 +189,66 @@ func (s *TaskService) Update(ctx context.Context, taskID int, req UpdateTaskRequ
 		return nil, err
 	}
 
-	parseDueDate(&task)
+	parseTask(&task)
 	return &task, nil
 }
 
+// TagUpdate describes a tag change applied by TaskService.UpdateTags.
+type TagUpdate struct {
+	// Add contains the tags to add to the task.
+	Add []string
+	// Remove contains the tags to remove from the task.
+	Remove []string
+	// IfUnmodifiedSince enables optimistic conflict detection when non-zero.
+	// If the task was updated after this time, no change is made and an
+	// error wrapping ErrConflict is returned. Typically set to the UpdatedAt
+	// of the task the caller last read.
+	//
+	// The check is best-effort: the API has no conditional updates, so it is
+	// made against the task fetched by UpdateTags. An edit made between that
+	// fetch and the update is not detected and is overwritten.
+	IfUnmodifiedSince time.Time
+}
+
+// AddTags adds tags to a task while keeping its existing tags.
+func (s *TaskService) AddTags(ctx context.Context, taskID int, tags ...string) (*Task, error) {
+	return s.UpdateTags(ctx, taskID, TagUpdate{Add: tags})
+}
+
+// RemoveTags removes tags from a task while keeping its other tags.
+func (s *TaskService) RemoveTags(ctx context.Context, taskID int, tags ...string) (*Task, error) {
+	return s.UpdateTags(ctx, taskID, TagUpdate{Remove: tags})
+}
+
+// UpdateTags fetches the task, merges the tag changes into its current tags
+// and writes the result back. If the tags are already in the desired state,
+// no update request is sent and the fetched task is returned. Tag changes
+// made by others between the fetch and the update are lost, even with
+// IfUnmodifiedSince set.
+func (s *TaskService) UpdateTags(ctx context.Context, taskID int, update TagUpdate) (*Task, error) {
+	task, err := s.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !update.IfUnmodifiedSince.IsZero() && task.UpdatedAt.After(update.IfUnmodifiedSince) {
+		return nil, fmt.Errorf("update tags of task %d: %w (updated at %s)",
+			taskID, ErrConflict, task.UpdatedAt.Format(time.RFC3339))
+	}
+
+	tags := ParseTags(task.TagsAsText)
+	before := tags.String()
+	tags.Add(update.Add...)
+	tags.Remove(update.Remove...)
+
+	after := tags.String()
+	if after == before {
+		return task, nil
+	}
+
+	return s.Update(ctx, taskID, UpdateTaskRequest{Tags: &after})
+}
+
 // Delete permanently deletes a task.
 func (s *TaskService) Delete(ctx context.Context, taskID int) error {
 	path := fmt.Sprintf("/checklists/%d/tasks/%d.json", s.checklistID, taskID)
@@ -214,7 +269,7 @@ func (s *TaskService) Close(ctx context.Context, taskID int) (*Task, error) {
 		return nil, fmt.Errorf("close task: unexpected empty response")
 	}
 
-	parseDueDate(&tasks[0])
+	parseTask(&tasks[0])
 	return &tasks[0], nil
 }
 
@@ -232,7 +287,7 @@ func (s *TaskService) Reopen(ctx context.Context, taskID int) (*Task, error) {
 		return nil, fmt.Errorf("reopen task: unexpected empty response")
 	}
 
-	parseDueDate(&tasks[0])
+	parseTask(&tasks[0])
 	return &tasks[0], nil
 }
 
@@ -250,10 +305,16 @@ func (s *TaskService) Invalidate(ctx context.Context, taskID int) (*Task, error)
 		return nil, fmt.Errorf("invalidate task: unexpected empty response")
 	}
 
-	parseDueDate(&tasks[0])
+	parseTask(&tasks[0])
 	return &tasks[0], nil
 }
 
+// parseTask fills the derived fields of a task decoded from an API response.
+func parseTask(task *Task) {
+	parseDueDate(task)
+	task.Tags = ParseTags(task.TagsAsText)
+}
+
 // parseDueDate attempts to parse the DueDateRaw string into a time.Time.
 // It supports the Checkvist API format (YYYY/MM/DD) and ISO 8601 format (YYYY-MM-DD).
 func parseDueDate(task *Task) {
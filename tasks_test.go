@@ -3,6 +3,7 @@ package checkvist
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -42,6 +43,12 @@ func TestTasks_List(t *testing.T) {
 	if tasks[1].Priority != 1 {
 		t.Errorf("expected priority 1, got %d", tasks[1].Priority)
 	}
+	if tasks[1].Tags.String() != "important, urgent" {
+		t.Errorf("expected parsed tags 'important, urgent', got %q", tasks[1].Tags.String())
+	}
+	if tasks[0].Tags == nil || len(tasks[0].Tags) != 0 {
+		t.Errorf("expected empty non-nil tags, got %v", tasks[0].Tags)
+	}
 }
 
 func TestTasks_Get(t *testing.T) {
@@ -801,3 +808,135 @@ func TestTasks_Create_WithTags_RealAPIFormat(t *testing.T) {
 		t.Skip("KNOWN BUG: Tags not sent to API - task wrapper format required")
 	}
 }
+
+// tagServer returns a test server holding a single task (ID 101) whose tags
+// can be read and updated. The number of PUT requests is recorded in puts.
+func tagServer(t *testing.T, tagsAsText string, updatedAt time.Time, puts *int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/1/tasks/101.json":
+			if r.Method == http.MethodPut {
+				*puts++
+				var req updateTaskWrapper
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatalf("failed to decode request: %v", err)
+				}
+				if req.Task.Tags == nil {
+					t.Fatal("expected tags in update request")
+				}
+				if req.Task.Content != nil {
+					t.Errorf("expected only tags to be updated, got content %q", *req.Task.Content)
+				}
+				tagsAsText = *req.Task.Tags
+				updatedAt = time.Now()
+			}
+			json.NewEncoder(w).Encode(Task{
+				ID:         101,
+				Content:    "Tagged task",
+				TagsAsText: tagsAsText,
+				UpdatedAt:  NewAPITime(updatedAt),
+			})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestTasks_AddTags(t *testing.T) {
+	var puts int
+	server := tagServer(t, "work, urgent", time.Now(), &puts)
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	task, err := client.Tasks(1).AddTags(context.Background(), 101, "Home", "work")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.TagsAsText != "home, urgent, work" {
+		t.Errorf("expected 'home, urgent, work', got %q", task.TagsAsText)
+	}
+	if puts != 1 {
+		t.Errorf("expected 1 update request, got %d", puts)
+	}
+}
+
+func TestTasks_RemoveTags(t *testing.T) {
+	var puts int
+	server := tagServer(t, "work, urgent", time.Now(), &puts)
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	task, err := client.Tasks(1).RemoveTags(context.Background(), 101, "URGENT")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.TagsAsText != "work" {
+		t.Errorf("expected 'work', got %q", task.TagsAsText)
+	}
+	if !task.Tags.Has("work") || task.Tags.Has("urgent") {
+		t.Errorf("expected parsed tags [work], got %v", task.Tags)
+	}
+}
+
+func TestTasks_UpdateTags_NoChange(t *testing.T) {
+	var puts int
+	server := tagServer(t, "work", time.Now(), &puts)
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	if _, err := client.Tasks(1).AddTags(context.Background(), 101, "work"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if puts != 0 {
+		t.Errorf("expected no update request, got %d", puts)
+	}
+}
+
+func TestTasks_UpdateTags_Conflict(t *testing.T) {
+	lastRead := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("modified since last read", func(t *testing.T) {
+		var puts int
+		server := tagServer(t, "work", lastRead.Add(time.Minute), &puts)
+		defer server.Close()
+
+		client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+		_, err := client.Tasks(1).UpdateTags(context.Background(), 101, TagUpdate{
+			Add:               []string{"urgent"},
+			IfUnmodifiedSince: lastRead,
+		})
+
+		if !errors.Is(err, ErrConflict) {
+			t.Fatalf("expected ErrConflict, got %v", err)
+		}
+		if puts != 0 {
+			t.Errorf("expected no update request, got %d", puts)
+		}
+	})
+
+	t.Run("unmodified", func(t *testing.T) {
+		var puts int
+		server := tagServer(t, "work", lastRead, &puts)
+		defer server.Close()
+
+		client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+		_, err := client.Tasks(1).UpdateTags(context.Background(), 101, TagUpdate{
+			Add:               []string{"urgent"},
+			IfUnmodifiedSince: lastRead,
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if puts != 1 {
+			t.Errorf("expected 1 update request, got %d", puts)
+		}
+	})
+}
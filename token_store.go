@@ -0,0 +1,227 @@
+package checkvist
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// token_store.go contains the TokenStore interface for persisting
+// authentication tokens across processes, with file-based and in-memory
+// implementations.
+
+// Token is a persisted authentication token.
+type Token struct {
+	// Value is the token sent in the X-Client-Token header.
+	Value string `json:"token"`
+	// ExpiresAt is the time after which the client renews the token.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TokenStore persists authentication tokens so that they can be reused by
+// later clients, e.g. across short-lived CLI invocations. Tokens are keyed
+// by username.
+//
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Load returns the stored token for the key, or nil if there is none.
+	Load(ctx context.Context, key string) (*Token, error)
+	// Save stores the token for the key, replacing any previous token.
+	Save(ctx context.Context, key string, token Token) error
+	// Clear removes the stored token for the key. Clearing a missing token is not an error.
+	Clear(ctx context.Context, key string) error
+}
+
+// MemoryTokenStore is a TokenStore that keeps tokens in memory.
+// It is useful for sharing a token between several clients in one process.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+// NewMemoryTokenStore creates an empty in-memory token store.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]Token)}
+}
+
+// Load implements TokenStore.
+func (s *MemoryTokenStore) Load(_ context.Context, key string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// Save implements TokenStore.
+func (s *MemoryTokenStore) Save(_ context.Context, key string, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[key] = token
+	return nil
+}
+
+// Clear implements TokenStore.
+func (s *MemoryTokenStore) Clear(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, key)
+	return nil
+}
+
+// FileTokenStore is a TokenStore that keeps one JSON file per key in a
+// directory. Files are created with 0600 permissions and the directory with
+// 0700. File names are derived from a hash of the key, so usernames never
+// appear in the file system.
+type FileTokenStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileTokenStore creates a token store that persists tokens in dir.
+// The directory is created on the first Save if it does not exist.
+func NewFileTokenStore(dir string) *FileTokenStore {
+	return &FileTokenStore{dir: dir}
+}
+
+// DefaultTokenStoreDir returns the default directory for a FileTokenStore,
+// located in the user's cache directory.
+func DefaultTokenStoreDir() (string, error) {
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("locating cache directory: %w", err)
+	}
+	return filepath.Join(cache, "checkvist", "tokens"), nil
+}
+
+// path returns the file path for the key.
+func (s *FileTokenStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Load implements TokenStore.
+func (s *FileTokenStore) Load(_ context.Context, key string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading token file: %w", err)
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("decoding token file: %w", err)
+	}
+	return &token, nil
+}
+
+// Save implements TokenStore. The file is replaced atomically.
+func (s *FileTokenStore) Save(_ context.Context, key string, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("creating token directory: %w", err)
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("encoding token: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".token-*")
+	if err != nil {
+		return fmt.Errorf("creating token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("setting token file permissions: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing token file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		return fmt.Errorf("replacing token file: %w", err)
+	}
+	return nil
+}
+
+// Clear implements TokenStore.
+func (s *FileTokenStore) Clear(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing token file: %w", err)
+	}
+	return nil
+}
+
+// loadStoredToken loads a token from the token store into the client.
+// It reports whether a token was loaded. Store errors are logged and ignored
+// so that a broken store never prevents authentication.
+func (c *Client) loadStoredToken(ctx context.Context) bool {
+	if c.tokenStore == nil {
+		return false
+	}
+	token, err := c.tokenStore.Load(ctx, c.username)
+	if err != nil {
+		c.logger.Debug("loading stored token failed", "error", err)
+		return false
+	}
+	if token == nil || token.Value == "" || !time.Now().Before(token.ExpiresAt) {
+		return false
+	}
+
+	c.mu.Lock()
+	c.token = token.Value
+	c.tokenExp = token.ExpiresAt
+	c.mu.Unlock()
+
+	c.logger.Debug("loaded stored token", "username", c.username)
+	return true
+}
+
+// setToken sets the client's token and persists it in the token store, if any.
+func (c *Client) setToken(ctx context.Context, token string, exp time.Time) {
+	c.mu.Lock()
+	c.token = token
+	c.tokenExp = exp
+	c.mu.Unlock()
+
+	if c.tokenStore != nil {
+		if err := c.tokenStore.Save(ctx, c.username, Token{Value: token, ExpiresAt: exp}); err != nil {
+			c.logger.Debug("saving token failed", "error", err)
+		}
+	}
+}
+
+// clearStoredToken removes the client's token from the token store, if any.
+func (c *Client) clearStoredToken(ctx context.Context) {
+	if c.tokenStore == nil {
+		return
+	}
+	if err := c.tokenStore.Clear(ctx, c.username); err != nil {
+		c.logger.Debug("clearing stored token failed", "error", err)
+	}
+}
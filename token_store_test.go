@@ -0,0 +1,201 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenStores(t *testing.T) {
+	stores := map[string]TokenStore{
+		"memory": NewMemoryTokenStore(),
+		"file":   NewFileTokenStore(filepath.Join(t.TempDir(), "tokens")),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			exp := time.Now().Add(time.Hour).Truncate(time.Second)
+
+			token, err := store.Load(ctx, "alice@example.com")
+			if err != nil || token != nil {
+				t.Fatalf("expected no token, got %v, %v", token, err)
+			}
+
+			if err := store.Save(ctx, "alice@example.com", Token{Value: "alice-token", ExpiresAt: exp}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := store.Save(ctx, "bob@example.com", Token{Value: "bob-token", ExpiresAt: exp}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token, err = store.Load(ctx, "alice@example.com")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token.Value != "alice-token" || !token.ExpiresAt.Equal(exp) {
+				t.Errorf("unexpected token: %+v", token)
+			}
+
+			if err := store.Clear(ctx, "alice@example.com"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := store.Clear(ctx, "alice@example.com"); err != nil {
+				t.Errorf("expected clearing a missing token to succeed, got %v", err)
+			}
+			if token, _ := store.Load(ctx, "alice@example.com"); token != nil {
+				t.Errorf("expected cleared token, got %+v", token)
+			}
+			if token, _ := store.Load(ctx, "bob@example.com"); token == nil || token.Value != "bob-token" {
+				t.Errorf("expected bob's token to be unaffected, got %+v", token)
+			}
+		})
+	}
+}
+
+func TestFileTokenStore_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+
+	dir := filepath.Join(t.TempDir(), "tokens")
+	store := NewFileTokenStore(dir)
+	if err := store.Save(context.Background(), "user@example.com", Token{Value: "secret"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(store.path("user@example.com"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected file permissions 0600, got %o", perm)
+	}
+	dirInfo, err := os.Stat(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if perm := dirInfo.Mode().Perm(); perm != 0o700 {
+		t.Errorf("expected directory permissions 0700, got %o", perm)
+	}
+}
+
+// tokenStoreServer counts login requests and records the token used for API calls.
+func tokenStoreServer(t *testing.T, logins *int32, usedToken *atomic.Value) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			atomic.AddInt32(logins, 1)
+			json.NewEncoder(w).Encode(map[string]string{"token": "fresh-token"})
+		case "/checklists.json":
+			usedToken.Store(r.Header.Get("X-Client-Token"))
+			w.Write([]byte("[]"))
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestClient_TokenStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reuses stored token", func(t *testing.T) {
+		var logins int32
+		var used atomic.Value
+		server := tokenStoreServer(t, &logins, &used)
+		defer server.Close()
+
+		store := NewMemoryTokenStore()
+		store.Save(ctx, "user@example.com", Token{Value: "stored-token", ExpiresAt: time.Now().Add(12 * time.Hour)})
+
+		client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL), WithTokenStore(store))
+		if _, err := client.Checklists().List(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if logins != 0 {
+			t.Errorf("expected no login, got %d", logins)
+		}
+		if used.Load() != "stored-token" {
+			t.Errorf("expected stored token to be used, got %v", used.Load())
+		}
+	})
+
+	t.Run("saves token after login", func(t *testing.T) {
+		var logins int32
+		var used atomic.Value
+		server := tokenStoreServer(t, &logins, &used)
+		defer server.Close()
+
+		store := NewMemoryTokenStore()
+		client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL), WithTokenStore(store))
+		if _, err := client.Checklists().List(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		token, _ := store.Load(ctx, "user@example.com")
+		if token == nil || token.Value != "fresh-token" {
+			t.Fatalf("expected fresh token to be saved, got %+v", token)
+		}
+
+		second := NewClient("user@example.com", "api-key", WithBaseURL(server.URL), WithTokenStore(store))
+		if _, err := second.Checklists().List(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if logins != 1 {
+			t.Errorf("expected a single login across clients, got %d", logins)
+		}
+	})
+
+	t.Run("ignores expired token", func(t *testing.T) {
+		var logins int32
+		var used atomic.Value
+		server := tokenStoreServer(t, &logins, &used)
+		defer server.Close()
+
+		store := NewMemoryTokenStore()
+		store.Save(ctx, "user@example.com", Token{Value: "expired-token", ExpiresAt: time.Now().Add(-time.Minute)})
+
+		client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL), WithTokenStore(store))
+		if _, err := client.Checklists().List(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if logins != 1 || used.Load() != "fresh-token" {
+			t.Errorf("expected a fresh login, got %d logins using %v", logins, used.Load())
+		}
+	})
+	t.Run("clears stored token only on rejected login", func(t *testing.T) {
+		for status, kept := range map[int]bool{
+			http.StatusServiceUnavailable: true,
+			http.StatusTooManyRequests:    true,
+			http.StatusUnauthorized:       false,
+		} {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}))
+
+			store := NewMemoryTokenStore()
+			store.Save(ctx, "user@example.com", Token{Value: "stored-token", ExpiresAt: time.Now().Add(12 * time.Hour)})
+
+			client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL), WithTokenStore(store))
+			if err := client.Authenticate(ctx); err == nil {
+				t.Errorf("status %d: expected login error", status)
+			}
+			server.Close()
+
+			token, _ := store.Load(ctx, "user@example.com")
+			if (token != nil) != kept {
+				t.Errorf("status %d: expected stored token kept=%v, got %+v", status, kept, token)
+			}
+		}
+	})
+}
@@ -0,0 +1,287 @@
+package checkvist
+
+import (
+	"context"
+	"sort"
+)
+
+// tree.go contains the TaskTree type for assembling the flat task list
+// returned by the API into a parent/child hierarchy.
+
+// TaskNode is a single task within a TaskTree.
+type TaskNode struct {
+	// Task is the task this node wraps.
+	Task Task
+	// Parent is the parent node, or nil for root, orphaned and cyclic tasks.
+	Parent *TaskNode
+	// Children contains the child nodes ordered by Position.
+	Children []*TaskNode
+}
+
+// TaskTree is a task hierarchy built from a flat list of tasks.
+//
+// The hierarchy is derived from Task.ParentID. Tasks whose parent is not part
+// of the list are reported as orphans and treated as roots so they stay
+// reachable. Tasks whose parent chain loops back onto itself are reported as
+// cycles; they can still be looked up by ID but are detached from the tree.
+// Tasks whose parent is part of a cycle are not themselves cyclic; they are
+// reported as orphans and treated as roots like tasks with a missing parent.
+type TaskTree struct {
+	nodes   map[int]*TaskNode
+	roots   []*TaskNode
+	orphans []*TaskNode
+	cycles  [][]int
+}
+
+// NewTaskTree builds a TaskTree from a flat list of tasks, as returned by
+// TaskService.List. If the list contains duplicate IDs, the first task wins.
+func NewTaskTree(tasks []Task) *TaskTree {
+	tree := &TaskTree{nodes: make(map[int]*TaskNode, len(tasks))}
+
+	order := make([]*TaskNode, 0, len(tasks))
+	for _, task := range tasks {
+		if _, ok := tree.nodes[task.ID]; ok {
+			continue
+		}
+		node := &TaskNode{Task: task}
+		tree.nodes[task.ID] = node
+		order = append(order, node)
+	}
+
+	cyclic := tree.detectCycles(order)
+
+	for _, node := range order {
+		if cyclic[node.Task.ID] {
+			continue
+		}
+		parentID := node.Task.ParentID
+		if parentID == 0 {
+			tree.roots = append(tree.roots, node)
+			continue
+		}
+		parent, ok := tree.nodes[parentID]
+		if !ok || cyclic[parentID] {
+			tree.orphans = append(tree.orphans, node)
+			tree.roots = append(tree.roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortNodes(tree.roots)
+	for _, node := range order {
+		sortNodes(node.Children)
+	}
+
+	return tree
+}
+
+// detectCycles follows the parent chain of every node and records each loop
+// once. It returns the set of task IDs that are part of a cycle.
+func (t *TaskTree) detectCycles(order []*TaskNode) map[int]bool {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[int]int, len(order))
+	cyclic := make(map[int]bool)
+
+	for _, start := range order {
+		var path []int
+		id := start.Task.ID
+		looped := false
+		for {
+			if state[id] != unvisited {
+				looped = state[id] == visiting
+				break
+			}
+			state[id] = visiting
+			path = append(path, id)
+			id = t.nodes[id].Task.ParentID
+			if _, ok := t.nodes[id]; !ok {
+				break
+			}
+		}
+		if looped {
+			for i, pid := range path {
+				if pid == id {
+					cycle := append([]int(nil), path[i:]...)
+					t.cycles = append(t.cycles, cycle)
+					for _, cid := range cycle {
+						cyclic[cid] = true
+					}
+					break
+				}
+			}
+		}
+		for _, pid := range path {
+			state[pid] = done
+		}
+	}
+
+	return cyclic
+}
+
+// sortNodes orders sibling nodes by Position, falling back to ID for ties.
+func sortNodes(nodes []*TaskNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Task.Position != nodes[j].Task.Position {
+			return nodes[i].Task.Position < nodes[j].Task.Position
+		}
+		return nodes[i].Task.ID < nodes[j].Task.ID
+	})
+}
+
+// Tree fetches all tasks in the checklist and assembles them into a TaskTree.
+func (s *TaskService) Tree(ctx context.Context) (*TaskTree, error) {
+	tasks, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaskTree(tasks), nil
+}
+
+// Len returns the number of tasks in the tree, including orphans and cycles.
+func (t *TaskTree) Len() int {
+	return len(t.nodes)
+}
+
+// Node returns the node for the given task ID, or nil if it is not in the tree.
+func (t *TaskTree) Node(id int) *TaskNode {
+	return t.nodes[id]
+}
+
+// Roots returns the top-level nodes ordered by Position.
+// Orphaned tasks are included as roots.
+func (t *TaskTree) Roots() []*TaskNode {
+	return t.roots
+}
+
+// Orphans returns the nodes whose ParentID refers to a task that is not in
+// the tree or that is part of a cycle.
+func (t *TaskTree) Orphans() []*TaskNode {
+	return t.orphans
+}
+
+// Cycles returns the task IDs of every parent chain that loops back onto itself.
+// Each cycle is listed in child-to-parent order.
+func (t *TaskTree) Cycles() [][]int {
+	return t.cycles
+}
+
+// Walk visits every node reachable from the roots in pre-order
+// (parents before their children). Returning false from fn stops the walk.
+func (t *TaskTree) Walk(fn func(*TaskNode) bool) {
+	for _, root := range t.roots {
+		if !root.walkPre(fn) {
+			return
+		}
+	}
+}
+
+// WalkPostOrder visits every node reachable from the roots in post-order
+// (children before their parents). Returning false from fn stops the walk.
+func (t *TaskTree) WalkPostOrder(fn func(*TaskNode) bool) {
+	for _, root := range t.roots {
+		if !root.walkPost(fn) {
+			return
+		}
+	}
+}
+
+// Tasks returns all tasks reachable from the roots in pre-order.
+func (t *TaskTree) Tasks() []Task {
+	result := make([]Task, 0, len(t.nodes))
+	t.Walk(func(n *TaskNode) bool {
+		result = append(result, n.Task)
+		return true
+	})
+	return result
+}
+
+// IsRoot reports whether the node has no parent in the tree.
+func (n *TaskNode) IsRoot() bool {
+	return n.Parent == nil
+}
+
+// IsLeaf reports whether the node has no children.
+func (n *TaskNode) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// Depth returns the number of ancestors of the node. Root nodes have depth 0.
+func (n *TaskNode) Depth() int {
+	depth := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Ancestors returns the ancestors of the node, starting with its parent
+// and ending with the root.
+func (n *TaskNode) Ancestors() []*TaskNode {
+	var result []*TaskNode
+	for p := n.Parent; p != nil; p = p.Parent {
+		result = append(result, p)
+	}
+	return result
+}
+
+// Path returns the nodes from the root down to and including this node.
+func (n *TaskNode) Path() []*TaskNode {
+	ancestors := n.Ancestors()
+	path := make([]*TaskNode, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		path = append(path, ancestors[i])
+	}
+	return append(path, n)
+}
+
+// Walk visits the node and its descendants in pre-order.
+// Returning false from fn stops the walk.
+func (n *TaskNode) Walk(fn func(*TaskNode) bool) {
+	n.walkPre(fn)
+}
+
+// WalkPostOrder visits the node's descendants and then the node itself.
+// Returning false from fn stops the walk.
+func (n *TaskNode) WalkPostOrder(fn func(*TaskNode) bool) {
+	n.walkPost(fn)
+}
+
+// Tasks returns the node's task followed by all of its descendants in pre-order.
+// The result can be passed to NewFilter to filter a single subtree.
+func (n *TaskNode) Tasks() []Task {
+	var result []Task
+	n.walkPre(func(node *TaskNode) bool {
+		result = append(result, node.Task)
+		return true
+	})
+	return result
+}
+
+// walkPre is the recursive pre-order walk. It reports whether to continue.
+func (n *TaskNode) walkPre(fn func(*TaskNode) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for _, child := range n.Children {
+		if !child.walkPre(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// walkPost is the recursive post-order walk. It reports whether to continue.
+func (n *TaskNode) walkPost(fn func(*TaskNode) bool) bool {
+	for _, child := range n.Children {
+		if !child.walkPost(fn) {
+			return false
+		}
+	}
+	return fn(n)
+}
@@ -0,0 +1,228 @@
+package checkvist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// treeFixture returns a small hierarchy:
+//
+//	1
+//	├── 3 (position 1)
+//	│   └── 5
+//	└── 2 (position 2)
+//	4
+func treeFixture() []Task {
+	return []Task{
+		{ID: 2, ParentID: 1, Position: 2, Content: "Child B"},
+		{ID: 1, ParentID: 0, Position: 1, Content: "Root A"},
+		{ID: 5, ParentID: 3, Position: 1, Content: "Grandchild"},
+		{ID: 3, ParentID: 1, Position: 1, Content: "Child A"},
+		{ID: 4, ParentID: 0, Position: 2, Content: "Root B"},
+	}
+}
+
+func nodeIDs(nodes []*TaskNode) []int {
+	ids := make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.Task.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNewTaskTree_Structure(t *testing.T) {
+	tree := NewTaskTree(treeFixture())
+
+	if tree.Len() != 5 {
+		t.Errorf("expected 5 nodes, got %d", tree.Len())
+	}
+	if got := nodeIDs(tree.Roots()); !equalIDs(got, []int{1, 4}) {
+		t.Errorf("expected roots [1 4], got %v", got)
+	}
+	if got := nodeIDs(tree.Node(1).Children); !equalIDs(got, []int{3, 2}) {
+		t.Errorf("expected children of 1 ordered [3 2], got %v", got)
+	}
+	if tree.Node(99) != nil {
+		t.Error("expected nil for unknown ID")
+	}
+	if len(tree.Orphans()) != 0 || len(tree.Cycles()) != 0 {
+		t.Errorf("expected no orphans or cycles, got %v / %v", nodeIDs(tree.Orphans()), tree.Cycles())
+	}
+}
+
+func TestTaskNode_AncestorsAndDepth(t *testing.T) {
+	tree := NewTaskTree(treeFixture())
+	node := tree.Node(5)
+
+	if node.Depth() != 2 {
+		t.Errorf("expected depth 2, got %d", node.Depth())
+	}
+	if got := nodeIDs(node.Ancestors()); !equalIDs(got, []int{3, 1}) {
+		t.Errorf("expected ancestors [3 1], got %v", got)
+	}
+	if got := nodeIDs(node.Path()); !equalIDs(got, []int{1, 3, 5}) {
+		t.Errorf("expected path [1 3 5], got %v", got)
+	}
+	if !tree.Node(1).IsRoot() || tree.Node(1).IsLeaf() {
+		t.Error("expected node 1 to be a root with children")
+	}
+	if !node.IsLeaf() {
+		t.Error("expected node 5 to be a leaf")
+	}
+}
+
+func TestTaskTree_Walk(t *testing.T) {
+	tree := NewTaskTree(treeFixture())
+
+	var pre, post []int
+	tree.Walk(func(n *TaskNode) bool {
+		pre = append(pre, n.Task.ID)
+		return true
+	})
+	tree.WalkPostOrder(func(n *TaskNode) bool {
+		post = append(post, n.Task.ID)
+		return true
+	})
+
+	if !equalIDs(pre, []int{1, 3, 5, 2, 4}) {
+		t.Errorf("unexpected pre-order: %v", pre)
+	}
+	if !equalIDs(post, []int{5, 3, 2, 1, 4}) {
+		t.Errorf("unexpected post-order: %v", post)
+	}
+
+	t.Run("stop early", func(t *testing.T) {
+		var visited []int
+		tree.Walk(func(n *TaskNode) bool {
+			visited = append(visited, n.Task.ID)
+			return n.Task.ID != 3
+		})
+		if !equalIDs(visited, []int{1, 3}) {
+			t.Errorf("expected walk to stop after 3, got %v", visited)
+		}
+	})
+
+	t.Run("subtree tasks", func(t *testing.T) {
+		var ids []int
+		for _, task := range tree.Node(3).Tasks() {
+			ids = append(ids, task.ID)
+		}
+		if !equalIDs(ids, []int{3, 5}) {
+			t.Errorf("expected subtree [3 5], got %v", ids)
+		}
+	})
+}
+
+func TestNewTaskTree_Orphans(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, ParentID: 0, Position: 1},
+		{ID: 2, ParentID: 42, Position: 2},
+	}
+	tree := NewTaskTree(tasks)
+
+	if got := nodeIDs(tree.Orphans()); !equalIDs(got, []int{2}) {
+		t.Errorf("expected orphans [2], got %v", got)
+	}
+	if got := nodeIDs(tree.Roots()); !equalIDs(got, []int{1, 2}) {
+		t.Errorf("expected orphan to be treated as root, got %v", got)
+	}
+}
+
+func TestNewTaskTree_Cycles(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, ParentID: 0},
+		{ID: 2, ParentID: 3},
+		{ID: 3, ParentID: 2},
+		{ID: 4, ParentID: 2},
+		{ID: 5, ParentID: 5},
+	}
+	tree := NewTaskTree(tasks)
+
+	cycles := tree.Cycles()
+	if len(cycles) != 2 {
+		t.Fatalf("expected 2 cycles, got %v", cycles)
+	}
+	if !equalIDs(cycles[0], []int{2, 3}) {
+		t.Errorf("expected first cycle [2 3], got %v", cycles[0])
+	}
+	if !equalIDs(cycles[1], []int{5}) {
+		t.Errorf("expected self-cycle [5], got %v", cycles[1])
+	}
+	if got := nodeIDs(tree.Roots()); !equalIDs(got, []int{1, 4}) {
+		t.Errorf("expected roots [1 4], got %v", got)
+	}
+	if got := nodeIDs(tree.Orphans()); !equalIDs(got, []int{4}) {
+		t.Errorf("expected task under cycle to be an orphan, got %v", got)
+	}
+	if tree.Node(4).Depth() != 0 {
+		t.Errorf("expected depth 0 for task under cycle, got %d", tree.Node(4).Depth())
+	}
+}
+
+func TestNewTaskTree_CycleDescendantsReachable(t *testing.T) {
+	tasks := []Task{
+		{ID: 1, ParentID: 2},
+		{ID: 2, ParentID: 1},
+		{ID: 3, ParentID: 1},
+		{ID: 5, ParentID: 3},
+		{ID: 4},
+	}
+	tree := NewTaskTree(tasks)
+
+	if tree.Len() != 5 {
+		t.Fatalf("expected 5 tasks, got %d", tree.Len())
+	}
+	var ids []int
+	for _, task := range tree.Tasks() {
+		ids = append(ids, task.ID)
+	}
+	if !equalIDs(ids, []int{3, 5, 4}) {
+		t.Errorf("expected every non-cyclic task to be reachable, got %v", ids)
+	}
+	if got := nodeIDs(tree.Orphans()); !equalIDs(got, []int{3}) {
+		t.Errorf("expected orphans [3], got %v", got)
+	}
+	if len(tree.Cycles()) != 1 {
+		t.Errorf("expected 1 cycle, got %v", tree.Cycles())
+	}
+}
+
+func TestTasks_Tree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/auth/login.json":
+			json.NewEncoder(w).Encode(map[string]string{"token": "test-token"})
+		case "/checklists/1/tasks.json":
+			json.NewEncoder(w).Encode(treeFixture())
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("user@example.com", "api-key", WithBaseURL(server.URL))
+	tree, err := client.Tasks(1).Tree(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := nodeIDs(tree.Roots()); !equalIDs(got, []int{1, 4}) {
+		t.Errorf("expected roots [1 4], got %v", got)
+	}
+}